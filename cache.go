@@ -0,0 +1,82 @@
+package ginSwagger
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CachePolicy holds the Cache-Control header values sent per kind of file.
+// Empty values send no Cache-Control header.
+type CachePolicy struct {
+	// Assets is sent with the static assets, e.g. swagger-ui-bundle.js.
+	Assets string
+	// Pages is sent with the generated pages, e.g. index.html and swagger-initializer.js.
+	Pages string
+	// Docs is sent with doc.json, doc.yaml, openapi.json and the documents listed in Specs.
+	Docs string
+}
+
+// RecommendedCachePolicy caches static assets for a year and makes browsers and CDNs
+// revalidate pages and documents on every use. Only use it when the asset URLs change
+// with every release of the assets, as immutable responses are never revalidated.
+var RecommendedCachePolicy = CachePolicy{
+	Assets: "public, max-age=31536000, immutable",
+	Pages:  "no-cache",
+	Docs:   "no-cache",
+}
+
+// contentVersion is the last known version of a generated file.
+type contentVersion struct {
+	etag     string
+	modified time.Time
+}
+
+// contentVersions tracks when each generated file last changed, so conditional
+// requests can be answered from Last-Modified as well as from the ETag.
+type contentVersions struct {
+	mu       sync.Mutex
+	versions map[string]contentVersion
+}
+
+// get returns the version of body, recording a new modification time when it changed.
+func (c *contentVersions) get(name string, body []byte) contentVersion {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.versions == nil {
+		c.versions = map[string]contentVersion{}
+	}
+
+	version, ok := c.versions[name]
+	if !ok || version.etag != etag {
+		// Last-Modified has a resolution of one second.
+		version = contentVersion{etag: etag, modified: time.Now().UTC().Truncate(time.Second)}
+		c.versions[name] = version
+	}
+
+	return version
+}
+
+// serveContent writes a generated file with a strong ETag and Last-Modified,
+// answering If-None-Match and If-Modified-Since with 304 Not Modified.
+func serveContent(ctx *gin.Context, name string, body []byte, version contentVersion, cacheControl string) {
+	setCacheControl(ctx, cacheControl)
+	ctx.Header("ETag", version.etag)
+
+	http.ServeContent(ctx.Writer, ctx.Request, name, version.modified, bytes.NewReader(body))
+}
+
+func setCacheControl(ctx *gin.Context, cacheControl string) {
+	if cacheControl != "" {
+		ctx.Header("Cache-Control", cacheControl)
+	}
+}
@@ -0,0 +1,115 @@
+package ginSwagger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	swaggerFiles "github.com/swaggo/files"
+	"github.com/swaggo/swag"
+)
+
+type mutableSwag struct {
+	doc string
+}
+
+func (s *mutableSwag) ReadDoc() string {
+	return s.doc
+}
+
+func performConditionalRequest(router *gin.Engine, target string, headers map[string]string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	for key, value := range headers {
+		r.Header.Set(key, value)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	return w
+}
+
+// cacheChangeDoc is changed by TestCacheDocChange.
+var cacheChangeDoc = &mutableSwag{}
+
+func init() {
+	swag.Register("cache", &mutableSwag{doc: `{"swagger": "2.0"}`})
+	swag.Register("cache_change", cacheChangeDoc)
+}
+
+func TestCacheHeaders(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/*any", wrapHandler(swaggerFiles.Handler, InstanceName("cache"), CacheControl(RecommendedCachePolicy)))
+
+		for _, target := range []string{"/index.html", "/index.css", "/swagger-initializer.js", "/doc.json", "/doc.yaml"} {
+			w := performRequest(http.MethodGet, target, router)
+			assert.Equal(t, http.StatusOK, w.Code, target)
+			assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"), target)
+			assert.Regexp(t, `^"[0-9a-f]{32}"$`, w.Header().Get("ETag"), target)
+			assert.NotEmpty(t, w.Header().Get("Last-Modified"), target)
+
+			etag := w.Header().Get("ETag")
+
+			w = performConditionalRequest(router, target, map[string]string{"If-None-Match": etag})
+			assert.Equal(t, http.StatusNotModified, w.Code, target)
+			assert.Empty(t, w.Body.String(), target)
+
+			w = performConditionalRequest(router, target, map[string]string{"If-None-Match": `"other"`})
+			assert.Equal(t, http.StatusOK, w.Code, target)
+
+			w = performConditionalRequest(router, target, map[string]string{
+				"If-Modified-Since": time.Now().Add(time.Minute).UTC().Format(http.TimeFormat),
+			})
+			assert.Equal(t, http.StatusNotModified, w.Code, target)
+
+			w = performConditionalRequest(router, target, map[string]string{
+				"If-Modified-Since": time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat),
+			})
+			assert.Equal(t, http.StatusOK, w.Code, target)
+		}
+
+		w := performRequest(http.MethodGet, "/swagger-ui-bundle.js", router)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "public, max-age=31536000, immutable", w.Header().Get("Cache-Control"))
+	})
+}
+
+func TestCacheDocChange(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		doc := cacheChangeDoc
+		doc.doc = `{"swagger": "2.0", "info": {"version": "1"}}`
+
+		router.GET("/*any", wrapHandler(swaggerFiles.Handler, InstanceName("cache_change")))
+
+		w := performRequest(http.MethodGet, "/doc.json", router)
+		assert.Empty(t, w.Header().Get("Cache-Control"))
+
+		etag := w.Header().Get("ETag")
+		assert.Equal(t, etag, performRequest(http.MethodGet, "/doc.json", router).Header().Get("ETag"))
+
+		doc.doc = `{"swagger": "2.0", "info": {"version": "2"}}`
+
+		w = performConditionalRequest(router, "/doc.json", map[string]string{"If-None-Match": etag})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotEqual(t, etag, w.Header().Get("ETag"))
+		assert.Equal(t, doc.doc, w.Body.String())
+	})
+}
+
+func TestCacheControl(t *testing.T) {
+	var cfg Config
+	assert.Equal(t, CachePolicy{}, cfg.CacheControl)
+
+	configFunc := CacheControl(RecommendedCachePolicy)
+	configFunc(&cfg)
+	assert.Equal(t, RecommendedCachePolicy, cfg.CacheControl)
+}
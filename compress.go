@@ -0,0 +1,203 @@
+package ginSwagger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+// Content codings the static assets are precompressed with, in order of preference.
+const (
+	encodingBrotli = "br"
+	encodingGzip   = "gzip"
+)
+
+// compressibleExtensions are the asset types worth compressing; images are already compressed.
+var compressibleExtensions = map[string]bool{
+	".css":  true,
+	".html": true,
+	".js":   true,
+	".json": true,
+	".map":  true,
+	".svg":  true,
+	".txt":  true,
+}
+
+// precompressedAsset holds the compressed variants of a static asset.
+type precompressedAsset struct {
+	once        sync.Once
+	modified    time.Time
+	contentType string
+	variants    map[string][]byte
+	err         error
+}
+
+// precompressor compresses static assets on first use and keeps the results in memory.
+type precompressor struct {
+	assets fs.FS
+	mu     sync.Mutex
+	files  map[string]*precompressedAsset
+}
+
+// get returns the compressed variants of the named asset.
+func (p *precompressor) get(name string) (*precompressedAsset, error) {
+	p.mu.Lock()
+
+	if p.files == nil {
+		p.files = map[string]*precompressedAsset{}
+	}
+
+	asset, ok := p.files[name]
+	if !ok {
+		asset = &precompressedAsset{}
+		p.files[name] = asset
+	}
+
+	p.mu.Unlock()
+
+	asset.once.Do(func() {
+		asset.modified, asset.contentType, asset.variants, asset.err = compressAsset(p.assets, name)
+	})
+
+	return asset, asset.err
+}
+
+// compressAsset returns the modification time, the content type and the compressed variants of
+// the named asset. The content type is that of the uncompressed data: http.ServeContent would
+// sniff the compressed bytes of types unknown by extension, such as source maps.
+func compressAsset(assets fs.FS, name string) (time.Time, string, map[string][]byte, error) {
+	info, err := fs.Stat(assets, name)
+	if err != nil {
+		return time.Time{}, "", nil, err
+	}
+
+	data, err := fs.ReadFile(assets, name)
+	if err != nil {
+		return time.Time{}, "", nil, err
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(name))
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+
+	var gz bytes.Buffer
+
+	gzipWriter, _ := gzip.NewWriterLevel(&gz, gzip.BestCompression)
+	if err := compress(gzipWriter, data); err != nil {
+		return time.Time{}, "", nil, err
+	}
+
+	var br bytes.Buffer
+
+	// The best brotli level takes seconds on swagger-ui-bundle.js for a few percent gain.
+	if err := compress(brotli.NewWriterLevel(&br, brotli.DefaultCompression), data); err != nil {
+		return time.Time{}, "", nil, err
+	}
+
+	return info.ModTime(), contentType, map[string][]byte{encodingGzip: gz.Bytes(), encodingBrotli: br.Bytes()}, nil
+}
+
+func compress(writer io.WriteCloser, data []byte) error {
+	if _, err := writer.Write(data); err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+// isCompressible reports whether the named asset is served precompressed.
+func isCompressible(name string) bool {
+	return compressibleExtensions[strings.ToLower(filepath.Ext(name))]
+}
+
+// negotiateEncoding picks brotli or gzip from an Accept-Encoding header,
+// honouring q-values; it returns an empty string when neither is acceptable.
+func negotiateEncoding(acceptEncoding string) string {
+	qualities := map[string]float64{}
+
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		params := strings.Split(part, ";")
+
+		coding := strings.ToLower(strings.TrimSpace(params[0]))
+		if coding == "" {
+			continue
+		}
+
+		quality := 1.0
+
+		for _, param := range params[1:] {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && strings.EqualFold(key, "q") {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = q
+				}
+			}
+		}
+
+		qualities[coding] = quality
+	}
+
+	best, bestQuality := "", 0.0
+
+	for _, coding := range []string{encodingBrotli, encodingGzip} {
+		quality, ok := qualities[coding]
+		if !ok {
+			quality, ok = qualities["*"]
+		}
+
+		if ok && quality > bestQuality {
+			best, bestQuality = coding, quality
+		}
+	}
+
+	return best
+}
+
+// serve writes the negotiated compressed variant of a static asset and reports
+// whether it did. Responses already being encoded by an outer middleware, such as
+// gin-contrib/gzip, are left alone to avoid double compression.
+func (p *precompressor) serve(ctx *gin.Context, name string) bool {
+	if !isCompressible(name) || ctx.Writer.Header().Get("Content-Encoding") != "" {
+		return false
+	}
+
+	ctx.Writer.Header().Add("Vary", "Accept-Encoding")
+
+	encoding := negotiateEncoding(ctx.GetHeader("Accept-Encoding"))
+	if encoding == "" {
+		return false
+	}
+
+	asset, err := p.get(name)
+	if err != nil {
+		return false
+	}
+
+	ctx.Header("Content-Encoding", encoding)
+
+	if ctx.Writer.Header().Get("Content-Type") == "" {
+		ctx.Header("Content-Type", asset.contentType)
+	}
+
+	// http.ServeContent leaves out Content-Length of encoded content; send it for whole responses,
+	// so HEAD requests get it too.
+	if ctx.GetHeader("Range") == "" {
+		ctx.Header("Content-Length", strconv.Itoa(len(asset.variants[encoding])))
+	}
+
+	http.ServeContent(ctx.Writer, ctx.Request, name, asset.modified, bytes.NewReader(asset.variants[encoding]))
+
+	return true
+}
@@ -0,0 +1,155 @@
+package ginSwagger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"testing"
+	"testing/fstest"
+
+	"github.com/andybalholm/brotli"
+	ginGzip "github.com/gin-contrib/gzip"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var compressAssets = fstest.MapFS{
+	"swagger-ui-bundle.js": &fstest.MapFile{Data: bytes.Repeat([]byte("window.ui = SwaggerUIBundle({});\n"), 512)},
+	"favicon-16x16.png":    &fstest.MapFile{Data: []byte("\x89PNG")},
+	"swagger-ui.css.map":   &fstest.MapFile{Data: bytes.Repeat([]byte(`{"version":3,"sources":["swagger-ui.css"]}`), 64)},
+}
+
+func decode(t *testing.T, encoding string, body []byte) []byte {
+	var reader io.Reader
+
+	switch encoding {
+	case "gzip":
+		gz, err := gzip.NewReader(bytes.NewReader(body))
+		require.NoError(t, err)
+
+		reader = gz
+	case "br":
+		reader = brotli.NewReader(bytes.NewReader(body))
+	default:
+		return body
+	}
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestPrecompressedAssets(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/*any", wrapFS(compressAssets, Precompress(true)))
+
+		original := compressAssets["swagger-ui-bundle.js"].Data
+
+		for acceptEncoding, expected := range map[string]string{
+			"gzip, deflate, br": "br",
+			"gzip":              "gzip",
+			"br;q=0.5, gzip":    "gzip",
+			"identity":          "",
+			"":                  "",
+		} {
+			w := performConditionalRequest(router, "/swagger-ui-bundle.js", map[string]string{"Accept-Encoding": acceptEncoding})
+			assert.Equal(t, http.StatusOK, w.Code, acceptEncoding)
+			assert.Equal(t, expected, w.Header().Get("Content-Encoding"), acceptEncoding)
+			assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"), acceptEncoding)
+			assert.Equal(t, "application/javascript", w.Header().Get("Content-Type"), acceptEncoding)
+			assert.Equal(t, original, decode(t, expected, w.Body.Bytes()), acceptEncoding)
+
+			if expected != "" {
+				assert.Less(t, w.Body.Len(), len(original), acceptEncoding)
+			}
+		}
+
+		w := performConditionalRequest(router, "/favicon-16x16.png", map[string]string{"Accept-Encoding": "gzip, br"})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+		assert.Empty(t, w.Header().Get("Vary"))
+	})
+}
+
+func TestPrecompressedAssetContentType(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/*any", wrapFS(compressAssets, Precompress(true)))
+
+		identity := performConditionalRequest(router, "/swagger-ui.css.map", map[string]string{"Accept-Encoding": "identity"})
+		assert.Equal(t, http.StatusOK, identity.Code)
+
+		contentType := identity.Header().Get("Content-Type")
+		// No type is registered for .map by default, so it is sniffed from the uncompressed data.
+		assert.NotEmpty(t, contentType)
+		assert.NotContains(t, []string{"application/x-gzip", "application/octet-stream"}, contentType)
+
+		for _, encoding := range []string{"gzip", "br"} {
+			w := performConditionalRequest(router, "/swagger-ui.css.map", map[string]string{"Accept-Encoding": encoding})
+			assert.Equal(t, http.StatusOK, w.Code, encoding)
+			assert.Equal(t, encoding, w.Header().Get("Content-Encoding"), encoding)
+			assert.Equal(t, contentType, w.Header().Get("Content-Type"), encoding)
+			assert.Equal(t, compressAssets["swagger-ui.css.map"].Data, decode(t, encoding, w.Body.Bytes()), encoding)
+		}
+	})
+}
+
+func TestPrecompressedAssetsDisabled(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/*any", wrapFS(compressAssets))
+
+		w := performConditionalRequest(router, "/swagger-ui-bundle.js", map[string]string{"Accept-Encoding": "gzip, br"})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+	})
+}
+
+func TestPrecompressedAssetsWithGzipMiddleware(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.Use(ginGzip.Gzip(ginGzip.BestSpeed))
+		router.GET("/*any", wrapFS(compressAssets, Precompress(true)))
+
+		w := performConditionalRequest(router, "/swagger-ui-bundle.js", map[string]string{"Accept-Encoding": "gzip, br"})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		assert.Equal(t, compressAssets["swagger-ui-bundle.js"].Data, decode(t, "gzip", w.Body.Bytes()))
+	})
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	assert.Equal(t, "br", negotiateEncoding("gzip, br"))
+	assert.Equal(t, "br", negotiateEncoding("*"))
+	assert.Equal(t, "gzip", negotiateEncoding("GZIP"))
+	assert.Equal(t, "gzip", negotiateEncoding("br;q=0, *;q=0.1"))
+	assert.Equal(t, "gzip", negotiateEncoding("br; q=0.2, gzip; q=0.8"))
+	assert.Equal(t, "", negotiateEncoding("gzip;q=0, br;q=0"))
+	assert.Equal(t, "", negotiateEncoding("deflate"))
+	assert.Equal(t, "", negotiateEncoding(""))
+}
+
+func TestPrecompress(t *testing.T) {
+	var cfg Config
+	assert.Equal(t, false, cfg.Precompress)
+
+	configFunc := Precompress(true)
+	configFunc(&cfg)
+	assert.Equal(t, true, cfg.Precompress)
+
+	configFunc = Precompress(false)
+	configFunc(&cfg)
+	assert.Equal(t, false, cfg.Precompress)
+}
@@ -0,0 +1,88 @@
+package ginSwagger
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORSPolicy lets pages of other origins, such as a developer portal or editor.swagger.io,
+// fetch the documents: doc.json, doc.yaml, openapi.json and the documents of Specs.
+// The UI, its pages and assets are never shared. The policy is applied by the handler
+// itself, independently of any CORS middleware of the router.
+type CORSPolicy struct {
+	// AllowOrigins lists the allowed origins, e.g. "https://editor.swagger.io", or "*" for any.
+	AllowOrigins []string
+	// AllowOriginFunc allows the origins it returns true for, in addition to AllowOrigins.
+	AllowOriginFunc func(origin string) bool
+	// AllowCredentials lets requests carry cookies and Authorization headers, e.g. for Guards.
+	// Credentials are only shared with the origins of AllowOrigins and AllowOriginFunc,
+	// so it cannot be combined with "*".
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache the answer to a preflight request. Defaults to
+	// the browser default when zero.
+	MaxAge time.Duration
+}
+
+// allowOrigin reports whether origin may fetch the documents, and whether it is allowed
+// by name or by AllowOriginFunc rather than only by "*".
+func (p *CORSPolicy) allowOrigin(origin string) (allowed, named bool) {
+	if containsString(p.AllowOrigins, origin) || (p.AllowOriginFunc != nil && p.AllowOriginFunc(origin)) {
+		return true, true
+	}
+
+	return containsString(p.AllowOrigins, "*"), false
+}
+
+// setCORSHeaders sets the CORS headers of a request for a document, answering preflight
+// requests from allowed origins. It reports whether the request has been answered.
+func (p *CORSPolicy) setCORSHeaders(ctx *gin.Context) bool {
+	// Responses differ per origin, so caches must not share them across origins.
+	ctx.Writer.Header().Add("Vary", "Origin")
+
+	origin := ctx.GetHeader("Origin")
+	if origin == "" {
+		return false
+	}
+
+	allowed, named := p.allowOrigin(origin)
+	if !allowed {
+		return false
+	}
+
+	// Credentials are never shared with origins matched by "*" only.
+	credentials := p.AllowCredentials && named
+
+	allowOrigin := origin
+	if !credentials && containsString(p.AllowOrigins, "*") {
+		allowOrigin = "*"
+	}
+
+	ctx.Header("Access-Control-Allow-Origin", allowOrigin)
+
+	if credentials {
+		ctx.Header("Access-Control-Allow-Credentials", "true")
+	}
+
+	if ctx.Request.Method != http.MethodOptions || ctx.GetHeader("Access-Control-Request-Method") == "" {
+		ctx.Header("Access-Control-Expose-Headers", "ETag")
+
+		return false
+	}
+
+	ctx.Header("Access-Control-Allow-Methods", "GET, HEAD")
+
+	if headers := ctx.GetHeader("Access-Control-Request-Headers"); headers != "" {
+		ctx.Header("Access-Control-Allow-Headers", headers)
+	}
+
+	if p.MaxAge > 0 {
+		ctx.Header("Access-Control-Max-Age", strconv.Itoa(int(p.MaxAge/time.Second)))
+	}
+
+	ctx.AbortWithStatus(http.StatusNoContent)
+
+	return true
+}
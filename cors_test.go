@@ -0,0 +1,111 @@
+package ginSwagger
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		const portal = "https://portal.example.com"
+
+		preflight := map[string]string{
+			"Origin":                         portal,
+			"Access-Control-Request-Method":  http.MethodGet,
+			"Access-Control-Request-Headers": "Authorization",
+		}
+
+		cases := []adapterCase{
+			{target: "/swagger/doc.json", headers: map[string]string{"Origin": portal}, status: http.StatusOK, header: map[string]string{
+				"Access-Control-Allow-Origin":      portal,
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Expose-Headers":    "ETag",
+				"Vary":                             "Origin",
+			}},
+			{target: "/swagger/openapi.json", headers: map[string]string{"Origin": "https://tool.internal.example.com"}, status: http.StatusOK, header: map[string]string{
+				"Access-Control-Allow-Origin": "https://tool.internal.example.com",
+			}},
+			{target: "/swagger/doc.yaml", headers: map[string]string{"Origin": "https://evil.example"}, status: http.StatusOK, header: map[string]string{
+				"Access-Control-Allow-Origin": "",
+				"Vary":                        "Origin",
+			}},
+			{target: "/swagger/doc.json", status: http.StatusOK, header: map[string]string{"Access-Control-Allow-Origin": ""}},
+			// The UI is never shared.
+			{target: "/swagger/index.html", headers: map[string]string{"Origin": portal}, status: http.StatusOK, header: map[string]string{
+				"Access-Control-Allow-Origin": "",
+				"Vary":                        "",
+			}},
+			// Preflights are answered before the guards, which the request itself must pass.
+			{method: http.MethodOptions, target: "/swagger/doc.json", headers: preflight, status: http.StatusNoContent, header: map[string]string{
+				"Access-Control-Allow-Origin":      portal,
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Allow-Methods":     "GET, HEAD",
+				"Access-Control-Allow-Headers":     "Authorization",
+				"Access-Control-Max-Age":           "600",
+				"Allow":                            "",
+			}},
+			{method: http.MethodOptions, target: "/swagger/doc.json", headers: map[string]string{"Origin": "https://evil.example", "Access-Control-Request-Method": http.MethodGet}, status: http.StatusNoContent, header: map[string]string{
+				"Access-Control-Allow-Origin": "",
+				"Allow":                       "GET, HEAD, OPTIONS",
+			}},
+			{target: "/swagger/doc.json", headers: map[string]string{"Origin": portal}, status: http.StatusOK},
+		}
+
+		handler := mount("/swagger/", InstanceName("validate"), OpenAPI3(true), CORS(CORSPolicy{
+			AllowOrigins:     []string{portal},
+			AllowOriginFunc:  func(origin string) bool { return strings.HasSuffix(origin, ".internal.example.com") },
+			AllowCredentials: true,
+			MaxAge:           10 * time.Minute,
+		}))
+
+		for _, c := range cases {
+			c.run(t, handler)
+		}
+
+		guarded := mount("/swagger/", InstanceName("validate"), Guards(BearerGuard("token")), CORS(CORSPolicy{AllowOrigins: []string{portal}}))
+
+		adapterCase{method: http.MethodOptions, target: "/swagger/doc.json", headers: preflight, status: http.StatusNoContent, header: map[string]string{
+			"Access-Control-Allow-Origin": portal,
+			"Access-Control-Max-Age":      "",
+		}}.run(t, guarded)
+		adapterCase{target: "/swagger/doc.json", headers: map[string]string{"Origin": portal}, status: http.StatusUnauthorized}.run(t, guarded)
+		adapterCase{target: "/swagger/doc.json", headers: map[string]string{"Origin": portal, "Authorization": "Bearer token"}, status: http.StatusOK, header: map[string]string{
+			"Access-Control-Allow-Origin": portal,
+		}}.run(t, guarded)
+	})
+}
+
+func TestCORSAnyOrigin(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		handler := mount("/swagger/", InstanceName("validate"), CORS(CORSPolicy{AllowOrigins: []string{"*"}}))
+
+		adapterCase{target: "/swagger/doc.json", headers: map[string]string{"Origin": "https://editor.swagger.io"}, status: http.StatusOK, header: map[string]string{
+			"Access-Control-Allow-Origin":      "*",
+			"Access-Control-Allow-Credentials": "",
+		}}.run(t, handler)
+
+		// Credentials are only shared with origins allowed by name, never through "*".
+		handler = mount("/swagger/", InstanceName("validate"), CORS(CORSPolicy{AllowOrigins: []string{"*", "https://portal.example.com"}, AllowCredentials: true}))
+
+		adapterCase{target: "/swagger/doc.json", headers: map[string]string{"Origin": "https://evil.example"}, status: http.StatusOK, header: map[string]string{
+			"Access-Control-Allow-Origin":      "*",
+			"Access-Control-Allow-Credentials": "",
+		}}.run(t, handler)
+		adapterCase{target: "/swagger/doc.json", headers: map[string]string{"Origin": "https://portal.example.com"}, status: http.StatusOK, header: map[string]string{
+			"Access-Control-Allow-Origin":      "https://portal.example.com",
+			"Access-Control-Allow-Credentials": "true",
+		}}.run(t, handler)
+	})
+}
+
+func TestCORSOption(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.CORS)
+
+	CORS(CORSPolicy{AllowOrigins: []string{"*"}, MaxAge: time.Hour})(&cfg)
+	assert.Equal(t, &CORSPolicy{AllowOrigins: []string{"*"}, MaxAge: time.Hour}, cfg.CORS)
+}
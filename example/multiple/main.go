@@ -21,6 +21,14 @@ func main() {
 	v2.Register(router)
 	router.GET("/swagger/v2/*any", ginSwagger.WrapHandler(swaggerFiles.NewHandler(), ginSwagger.InstanceName("v2")))
 
+	// Serve both versions in one UI with a spec selector
+	router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.NewHandler(),
+		ginSwagger.Specs(
+			ginSwagger.Spec{Name: "API v1", InstanceName: "v1"},
+			ginSwagger.Spec{Name: "API v2", InstanceName: "v2"},
+		),
+		ginSwagger.PrimarySpec("API v2")))
+
 	// Listen and Server in
 	_ = router.Run()
 }
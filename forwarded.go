@@ -0,0 +1,196 @@
+package ginSwagger
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// forwardedRequest holds the externally visible origin of a request.
+type forwardedRequest struct {
+	host   string
+	scheme string
+	prefix string
+}
+
+// parseTrustedProxies turns a list of IPs and CIDRs into networks.
+// Entries that are neither are skipped.
+func parseTrustedProxies(proxies []string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(proxies))
+
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				continue
+			}
+
+			if ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+
+		if _, network, err := net.ParseCIDR(proxy); err == nil {
+			networks = append(networks, network)
+		}
+	}
+
+	return networks
+}
+
+// isTrustedPeer reports whether the direct peer of the request is one of the trusted proxies.
+func isTrustedPeer(ctx *gin.Context, trusted []*net.IPNet) bool {
+	ip := peerIP(ctx)
+
+	return ip != nil && isTrustedIP(ip, trusted)
+}
+
+// peerIP returns the address of the direct peer of the request, or nil if it is not an IP.
+func peerIP(ctx *gin.Context) net.IP {
+	host, _, err := net.SplitHostPort(strings.TrimSpace(ctx.Request.RemoteAddr))
+	if err != nil {
+		host = ctx.Request.RemoteAddr
+	}
+
+	return net.ParseIP(host)
+}
+
+func isTrustedIP(ip net.IP, trusted []*net.IPNet) bool {
+	for _, network := range trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// clientIP returns the address of the client. X-Forwarded-For is only read when the direct
+// peer is a trusted proxy, walking it from the right, i.e. from the hop closest to us, to the
+// first address that is not a trusted proxy. Entries left of it may be forged by the client.
+func clientIP(ctx *gin.Context, trusted []*net.IPNet) net.IP {
+	ip := peerIP(ctx)
+	if ip == nil || !isTrustedIP(ip, trusted) {
+		return ip
+	}
+
+	hops := strings.Split(joinedValues(ctx.Request.Header, "X-Forwarded-For"), ",")
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			break
+		}
+
+		ip = hop
+		if !isTrustedIP(hop, trusted) {
+			break
+		}
+	}
+
+	return ip
+}
+
+// forwardingHeaders are the request headers resolveForwarded reads.
+const forwardingHeaders = "Forwarded, X-Forwarded-Host, X-Forwarded-Proto, X-Forwarded-Prefix"
+
+// resolveForwarded determines the host, scheme and path prefix the client used to reach us.
+// Forwarding headers are only honoured when the direct peer is a trusted proxy, and only their
+// last element, added by that proxy: earlier ones may come from the client. The RFC 7239
+// Forwarded header takes precedence over the X-Forwarded-* headers.
+func resolveForwarded(ctx *gin.Context, trusted []*net.IPNet) forwardedRequest {
+	req := forwardedRequest{host: ctx.Request.Host, scheme: "http"}
+	if ctx.Request.TLS != nil {
+		req.scheme = "https"
+	}
+
+	if !isTrustedPeer(ctx, trusted) {
+		return req
+	}
+
+	header := ctx.Request.Header
+
+	if host := lastValue(joinedValues(header, "X-Forwarded-Host")); host != "" {
+		req.host = host
+	}
+
+	if proto := lastValue(joinedValues(header, "X-Forwarded-Proto")); proto != "" {
+		req.scheme = strings.ToLower(proto)
+	}
+
+	if forwarded := joinedValues(header, "Forwarded"); forwarded != "" {
+		params := parseForwarded(forwarded)
+
+		if host := params["host"]; host != "" {
+			req.host = host
+		}
+
+		if proto := params["proto"]; proto != "" {
+			req.scheme = strings.ToLower(proto)
+		}
+	}
+
+	req.prefix = strings.TrimRight(lastValue(joinedValues(header, "X-Forwarded-Prefix")), "/")
+
+	return req
+}
+
+// parseForwarded returns the parameters of the last element of an RFC 7239 Forwarded header,
+// which is the one added by the proxy closest to us.
+func parseForwarded(value string) map[string]string {
+	params := map[string]string{}
+
+	for _, pair := range strings.Split(lastValue(value), ";") {
+		key, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			continue
+		}
+
+		params[strings.ToLower(key)] = strings.Trim(val, `"`)
+	}
+
+	return params
+}
+
+// joinedValues returns all lines of a header joined with commas, so the elements a proxy
+// appends as a separate line are seen as well.
+func joinedValues(header http.Header, name string) string {
+	return strings.Join(header.Values(name), ",")
+}
+
+// lastValue returns the last entry of a comma separated header value.
+func lastValue(value string) string {
+	return strings.TrimSpace(value[strings.LastIndex(value, ",")+1:])
+}
+
+// hostRewriter returns a DocTransformer that replaces host, schemes and basePath
+// with the values seen by the client.
+func hostRewriter(trustedProxies []string) DocTransformer {
+	trusted := parseTrustedProxies(trustedProxies)
+
+	return func(ctx *gin.Context, doc map[string]interface{}) error {
+		// The document differs per forwarded origin, so caches must not share it across them.
+		ctx.Writer.Header().Add("Vary", forwardingHeaders)
+
+		req := resolveForwarded(ctx, trusted)
+
+		basePath, _ := doc["basePath"].(string)
+		if req.prefix != "" {
+			basePath = req.prefix + "/" + strings.TrimLeft(basePath, "/")
+			basePath = strings.TrimRight(basePath, "/")
+		}
+
+		doc["host"] = req.host
+		doc["schemes"] = []string{req.scheme}
+
+		if basePath != "" {
+			doc["basePath"] = basePath
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,156 @@
+package ginSwagger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	swaggerFiles "github.com/swaggo/files"
+	"github.com/swaggo/swag"
+)
+
+type hostSwag struct{}
+
+func (s *hostSwag) ReadDoc() string {
+	return `{"swagger": "2.0", "host": "petstore.swagger.io:8080", "basePath": "/v2", "schemes": [], "paths": {}}`
+}
+
+func init() {
+	swag.Register("host", &hostSwag{})
+}
+
+func requestDoc(t *testing.T, router *gin.Engine, remoteAddr string, headers map[string]string) map[string]interface{} {
+	r := httptest.NewRequest(http.MethodGet, "/docs/doc.json", nil)
+	r.RemoteAddr = remoteAddr
+	r.Host = "api.internal:8080"
+
+	for key, value := range headers {
+		r.Header.Set(key, value)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var doc map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+
+	return doc
+}
+
+func TestRewriteHostHandler(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/docs/*any", wrapHandler(swaggerFiles.Handler, InstanceName("host"), RewriteHost("10.0.0.0/8", "192.168.1.1")))
+
+		doc := requestDoc(t, router, "172.16.0.1:1234", map[string]string{"X-Forwarded-Host": "evil.example.com"})
+		assert.Equal(t, "api.internal:8080", doc["host"])
+		assert.Equal(t, []interface{}{"http"}, doc["schemes"])
+		assert.Equal(t, "/v2", doc["basePath"])
+
+		r := httptest.NewRequest(http.MethodGet, "/docs/doc.json", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		assert.Contains(t, w.Header().Values("Vary"), "Forwarded, X-Forwarded-Host, X-Forwarded-Proto, X-Forwarded-Prefix")
+
+		doc = requestDoc(t, router, "10.1.2.3:1234", map[string]string{
+			"X-Forwarded-Host":   "evil.example.com, api.example.com",
+			"X-Forwarded-Proto":  "HTTPS",
+			"X-Forwarded-Prefix": "/gateway/",
+		})
+		assert.Equal(t, "api.example.com", doc["host"])
+		assert.Equal(t, []interface{}{"https"}, doc["schemes"])
+		assert.Equal(t, "/gateway/v2", doc["basePath"])
+
+		doc = requestDoc(t, router, "192.168.1.1:1234", map[string]string{
+			"X-Forwarded-Host": "ignored.example.com",
+			"Forwarded":        `for=192.0.2.60;host=evil.example.com, for=10.0.0.1;proto=https;host="docs.example.com"`,
+		})
+		assert.Equal(t, "docs.example.com", doc["host"])
+		assert.Equal(t, []interface{}{"https"}, doc["schemes"])
+	})
+}
+
+func TestRewriteHostHeaderLines(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/docs/*any", wrapHandler(swaggerFiles.Handler, InstanceName("host"), RewriteHost("10.0.0.0/8")))
+
+		r := httptest.NewRequest(http.MethodGet, "/docs/doc.json", nil)
+		r.RemoteAddr = "10.0.0.1:1234"
+
+		// Proxies may append their element as a separate header line.
+		r.Header.Add("X-Forwarded-Host", "evil.example")
+		r.Header.Add("X-Forwarded-Proto", "http")
+		r.Header.Add("X-Forwarded-Prefix", "/evil")
+		r.Header.Add("X-Forwarded-Host", "real.example")
+		r.Header.Add("X-Forwarded-Proto", "https")
+		r.Header.Add("X-Forwarded-Prefix", "/gateway")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var doc map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+		assert.Equal(t, "real.example", doc["host"])
+		assert.Equal(t, []interface{}{"https"}, doc["schemes"])
+		assert.Equal(t, "/gateway/v2", doc["basePath"])
+
+		r = httptest.NewRequest(http.MethodGet, "/docs/doc.json", nil)
+		r.RemoteAddr = "10.0.0.1:1234"
+		r.Header.Add("Forwarded", "host=evil.example;proto=http")
+		r.Header.Add("Forwarded", "host=docs.example;proto=https")
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+		assert.Equal(t, "docs.example", doc["host"])
+	})
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	networks := parseTrustedProxies([]string{"10.0.0.0/8", "127.0.0.1", "::1", "invalid", "300.0.0.0/8"})
+
+	assert.Len(t, networks, 3)
+	assert.Equal(t, "10.0.0.0/8", networks[0].String())
+	assert.Equal(t, "127.0.0.1/32", networks[1].String())
+	assert.Equal(t, "::1/128", networks[2].String())
+}
+
+func TestParseForwarded(t *testing.T) {
+	assert.Equal(t, map[string]string{"for": "192.0.2.43", "proto": "https", "host": "example.com"},
+		parseForwarded(`for=198.51.100.17;host=evil.example.com, For="192.0.2.43";proto=https;host=example.com`))
+	assert.Equal(t, map[string]string{}, parseForwarded("garbage"))
+}
+
+func TestRewriteHost(t *testing.T) {
+	var cfg Config
+	assert.Equal(t, false, cfg.RewriteHost)
+	assert.Len(t, cfg.docTransformers(), 0)
+
+	configFunc := RewriteHost("10.0.0.0/8")
+	configFunc(&cfg)
+	assert.Equal(t, true, cfg.RewriteHost)
+	assert.Equal(t, []string{"10.0.0.0/8"}, cfg.TrustedProxies)
+	assert.Len(t, cfg.docTransformers(), 1)
+
+	// RewriteHost and TrustedProxies add to each other's proxies in any order.
+	cfg = Config{}
+	TrustedProxies("10.0.0.1")(&cfg)
+	RewriteHost()(&cfg)
+	RewriteHost("192.168.0.1")(&cfg)
+	assert.Equal(t, []string{"10.0.0.1", "192.168.0.1"}, cfg.TrustedProxies)
+
+	cfg = Config{}
+	RewriteHost("192.168.0.1")(&cfg)
+	TrustedProxies("10.0.0.1")(&cfg)
+	assert.Equal(t, []string{"192.168.0.1", "10.0.0.1"}, cfg.TrustedProxies)
+}
@@ -0,0 +1,140 @@
+package ginSwagger
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Guard reports whether a request may access the documentation.
+// Any `func(*gin.Context) bool` can be used, e.g. a check of the session of the request.
+type Guard func(ctx *gin.Context) bool
+
+// BasicAuthGuard admits requests with HTTP Basic credentials matching accounts,
+// a map of usernames to passwords.
+func BasicAuthGuard(accounts map[string]string) Guard {
+	return func(ctx *gin.Context) bool {
+		username, password, ok := ctx.Request.BasicAuth()
+		if !ok {
+			return false
+		}
+
+		expected, known := accounts[username]
+
+		// Compare even for unknown users, so response times do not reveal valid usernames.
+		return secureCompare(password, expected) && known
+	}
+}
+
+// BearerGuard admits requests with an "Authorization: Bearer <token>" header holding one of tokens.
+func BearerGuard(tokens ...string) Guard {
+	return func(ctx *gin.Context) bool {
+		scheme, token, ok := strings.Cut(ctx.GetHeader("Authorization"), " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") {
+			return false
+		}
+
+		return containsSecret(tokens, strings.TrimSpace(token))
+	}
+}
+
+// APIKeyGuard admits requests with the header holding one of keys, e.g. APIKeyGuard("X-API-Key", key).
+func APIKeyGuard(header string, keys ...string) Guard {
+	return func(ctx *gin.Context) bool {
+		key := ctx.GetHeader(header)
+
+		return key != "" && containsSecret(keys, key)
+	}
+}
+
+// CIDRGuard admits requests whose client IP is in one of the IPs or CIDRs; entries that are
+// neither are skipped. The client IP is the direct peer of the request; X-Forwarded-For is only
+// read when the peer is one of the TrustedProxies of the configuration. gin.Engine.SetTrustedProxies
+// and ctx.ClientIP are not used, as gin trusts every proxy by default.
+func CIDRGuard(cidrs ...string) Guard {
+	networks := parseTrustedProxies(cidrs)
+
+	return func(ctx *gin.Context) bool {
+		ip := guardedClientIP(ctx)
+		if ip == nil {
+			return false
+		}
+
+		for _, network := range networks {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// clientIPKey is the key of the client IP resolved by admit in the gin.Context.
+const clientIPKey = "github.com/swaggo/gin-swagger/clientIP"
+
+// guardedClientIP returns the client IP resolved by admit, or the direct peer for guards called elsewhere.
+func guardedClientIP(ctx *gin.Context) net.IP {
+	if ip, ok := ctx.Get(clientIPKey); ok {
+		return ip.(net.IP)
+	}
+
+	return peerIP(ctx)
+}
+
+// secureCompare compares a and b in constant time, independent of their lengths.
+func secureCompare(a, b string) bool {
+	hashA, hashB := sha256.Sum256([]byte(a)), sha256.Sum256([]byte(b))
+
+	return subtle.ConstantTimeCompare(hashA[:], hashB[:]) == 1
+}
+
+func containsSecret(secrets []string, value string) bool {
+	found := false
+
+	for _, secret := range secrets {
+		if secureCompare(value, secret) {
+			found = true
+		}
+	}
+
+	return found
+}
+
+// admit runs the guards of the configuration and writes the denial response when one fails.
+// trusted are the proxies whose X-Forwarded-For is honoured by CIDRGuard.
+func (config *Config) admit(ctx *gin.Context, trusted []*net.IPNet) bool {
+	if len(config.Guards) != 0 {
+		ctx.Set(clientIPKey, clientIP(ctx, trusted))
+	}
+
+	for _, guard := range config.Guards {
+		if guard(ctx) {
+			continue
+		}
+
+		switch config.GuardDenyStatus {
+		case http.StatusNotFound:
+			ctx.String(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+			ctx.Abort()
+		case http.StatusForbidden:
+			ctx.AbortWithStatus(http.StatusForbidden)
+		default:
+			challenge := config.GuardChallenge
+			if challenge == "" {
+				challenge = `Basic realm="` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(config.Title) + `", charset="UTF-8"`
+			}
+
+			ctx.Header("WWW-Authenticate", challenge)
+			ctx.AbortWithStatus(http.StatusUnauthorized)
+		}
+
+		return false
+	}
+
+	return true
+}
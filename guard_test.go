@@ -0,0 +1,156 @@
+package ginSwagger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	swaggerFilesV2 "github.com/swaggo/files/v2"
+)
+
+var guardedTargets = []string{"/index.html", "/swagger-ui.css", "/doc.json", "/swagger-initializer.js"}
+
+func guardedRouter(options ...func(*Config)) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.GET("/*any", wrapFS(swaggerFilesV2.FS, append([]func(*Config){InstanceName("validate")}, options...)...))
+
+	return router
+}
+
+func performRequestFrom(router *gin.Engine, target, remoteAddr string, headers map[string]string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	r.RemoteAddr = remoteAddr
+
+	for key, value := range headers {
+		r.Header.Set(key, value)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestBasicAuthGuard(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		router := guardedRouter(Guards(BasicAuthGuard(map[string]string{"admin": "secret"})), func(c *Config) { c.Title = `API "docs"` })
+
+		for _, target := range guardedTargets {
+			w := performRequest(http.MethodGet, target, router)
+			assert.Equal(t, http.StatusUnauthorized, w.Code, target)
+			assert.Equal(t, `Basic realm="API \"docs\"", charset="UTF-8"`, w.Header().Get("WWW-Authenticate"), target)
+			assert.Empty(t, w.Body.String(), target)
+
+			r := performConditionalRequest(router, target, map[string]string{"Authorization": "Basic YWRtaW46c2VjcmV0"}) // admin:secret
+			assert.Equal(t, http.StatusOK, r.Code, target)
+
+			r = performConditionalRequest(router, target, map[string]string{"Authorization": "Basic YWRtaW46d3Jvbmc="}) // admin:wrong
+			assert.Equal(t, http.StatusUnauthorized, r.Code, target)
+
+			r = performConditionalRequest(router, target, map[string]string{"Authorization": "Basic Z3Vlc3Q6"}) // guest:
+			assert.Equal(t, http.StatusUnauthorized, r.Code, target)
+		}
+	})
+}
+
+func TestBearerAndAPIKeyGuards(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		router := guardedRouter(Guards(BearerGuard("token-1", "token-2")), GuardChallenge("Bearer"))
+
+		w := performRequest(http.MethodGet, "/doc.json", router)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+
+		assert.Equal(t, http.StatusOK, performConditionalRequest(router, "/doc.json", map[string]string{"Authorization": "Bearer token-2"}).Code)
+		assert.Equal(t, http.StatusOK, performConditionalRequest(router, "/doc.json", map[string]string{"Authorization": "bearer token-1"}).Code)
+		assert.Equal(t, http.StatusUnauthorized, performConditionalRequest(router, "/doc.json", map[string]string{"Authorization": "Bearer token-3"}).Code)
+		assert.Equal(t, http.StatusUnauthorized, performConditionalRequest(router, "/doc.json", map[string]string{"Authorization": "token-1"}).Code)
+
+		router = guardedRouter(Guards(APIKeyGuard("X-API-Key", "key")), GuardDenyStatus(http.StatusForbidden))
+
+		w = performRequest(http.MethodGet, "/doc.json", router)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Empty(t, w.Header().Get("WWW-Authenticate"))
+
+		assert.Equal(t, http.StatusOK, performConditionalRequest(router, "/doc.json", map[string]string{"X-API-Key": "key"}).Code)
+		assert.Equal(t, http.StatusForbidden, performConditionalRequest(router, "/doc.json", map[string]string{"X-API-Key": "other"}).Code)
+	})
+}
+
+func TestCIDRGuard(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		router := guardedRouter(Guards(CIDRGuard("10.0.0.0/8", "192.168.1.7", "not an ip")), GuardDenyStatus(http.StatusNotFound))
+
+		for remoteAddr, expected := range map[string]int{
+			"10.1.2.3:1234":    http.StatusOK,
+			"192.168.1.7:1234": http.StatusOK,
+			"192.168.1.8:1234": http.StatusNotFound,
+			"[::1]:1234":       http.StatusNotFound,
+		} {
+			r := performRequestFrom(router, "/index.html", remoteAddr, map[string]string{"X-Forwarded-For": "10.0.0.1"})
+			assert.Equal(t, expected, r.Code, remoteAddr)
+
+			if expected == http.StatusNotFound {
+				assert.Equal(t, http.StatusText(http.StatusNotFound), r.Body.String(), remoteAddr)
+			}
+		}
+	})
+}
+
+func TestCIDRGuardForwardedFor(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		// A default engine trusts every proxy, the guard must not.
+		router := guardedRouter(Guards(CIDRGuard("10.0.0.0/8")))
+
+		r := performRequestFrom(router, "/doc.json", "203.0.113.5:1234", map[string]string{"X-Forwarded-For": "10.1.2.3"})
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+
+		router = guardedRouter(Guards(CIDRGuard("10.0.0.0/8")), TrustedProxies("203.0.113.0/24"))
+
+		for forwardedFor, expected := range map[string]int{
+			"10.1.2.3":                      http.StatusOK,
+			"10.1.2.3, 203.0.113.7":         http.StatusOK,
+			"10.1.2.3, 198.51.100.1":        http.StatusUnauthorized,
+			"198.51.100.1, 10.1.2.3":        http.StatusOK,
+			"10.1.2.3, garbage":             http.StatusUnauthorized,
+			"":                              http.StatusUnauthorized,
+			"10.1.2.3, 203.0.113.7, 10.9.9": http.StatusUnauthorized,
+		} {
+			r := performRequestFrom(router, "/doc.json", "203.0.113.5:1234", map[string]string{"X-Forwarded-For": forwardedFor})
+			assert.Equal(t, expected, r.Code, forwardedFor)
+		}
+	})
+}
+
+func TestCustomGuards(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		internal := func(ctx *gin.Context) bool {
+			return ctx.GetHeader("X-Internal") == "true"
+		}
+
+		router := guardedRouter(Guards(internal, APIKeyGuard("X-API-Key", "key")))
+
+		assert.Equal(t, http.StatusUnauthorized, performConditionalRequest(router, "/index.html", map[string]string{"X-Internal": "true"}).Code)
+		assert.Equal(t, http.StatusUnauthorized, performConditionalRequest(router, "/index.html", map[string]string{"X-API-Key": "key"}).Code)
+		assert.Equal(t, http.StatusOK, performConditionalRequest(router, "/index.html", map[string]string{"X-Internal": "true", "X-API-Key": "key"}).Code)
+	})
+}
+
+func TestGuardOptions(t *testing.T) {
+	var cfg Config
+	assert.Empty(t, cfg.Guards)
+
+	Guards(BearerGuard("a"))(&cfg)
+	Guards(BearerGuard("b"), CIDRGuard("::1"))(&cfg)
+	assert.Len(t, cfg.Guards, 3)
+
+	GuardDenyStatus(http.StatusNotFound)(&cfg)
+	assert.Equal(t, http.StatusNotFound, cfg.GuardDenyStatus)
+
+	GuardChallenge("Bearer")(&cfg)
+	assert.Equal(t, "Bearer", cfg.GuardChallenge)
+}
@@ -0,0 +1,426 @@
+package ginSwagger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"github.com/swaggo/swag"
+)
+
+// errDisabled is returned for endpoints turned off in the configuration.
+var errDisabled = errors.New("ginSwagger: endpoint disabled")
+
+// Handler serves the documents and the frontend of one configuration.
+// The pages of the frontend are rendered once, when the handler is created or reconfigured.
+type Handler struct {
+	mu     sync.Mutex
+	assets fs.FS
+	state  atomic.Pointer[handlerState]
+}
+
+// handlerState is everything derived from one configuration. It is never modified
+// once built; Reconfigure swaps in a new state.
+type handlerState struct {
+	config       *Config
+	renderer     Renderer
+	assets       http.FileSystem
+	matcher      fileMatcher
+	source       docSource
+	transformers []DocTransformer
+	pages        map[string]renderedPage
+	openAPI3     convertedDoc
+	versions     contentVersions
+	compressed   *precompressor
+	// policy is the Content-Security-Policy of the HTML pages, with cspNonce in place of the nonce.
+	policy string
+	// trusted are the proxies whose forwarding headers are honoured by redirects and CIDRGuard.
+	trusted []*net.IPNet
+	// engine gives requests served through ServeHTTP a *gin.Context. It is built on first use,
+	// so handlers only mounted on gin routes never create one.
+	engine     *gin.Engine
+	engineOnce sync.Once
+}
+
+// renderedPage is a pre-rendered page of the frontend.
+type renderedPage struct {
+	body    []byte
+	version contentVersion
+	err     error
+}
+
+// NewHandler returns a Handler for a copy of config, serving Swagger UI with the static
+// assets found at the root of assets unless config selects another Renderer.
+func NewHandler(config *Config, assets fs.FS) *Handler {
+	h := &Handler{assets: assets}
+	h.state.Store(newHandlerState(*config, assets))
+
+	return h
+}
+
+// HandlerFunc returns the `gin.HandlerFunc` to mount, e.g. at "/swagger/*any".
+// Guards, transformers and other hooks receive the *gin.Context of the route.
+func (h *Handler) HandlerFunc() gin.HandlerFunc {
+	return h.serve
+}
+
+// ServeHTTP serves the documentation as an http.Handler, e.g. on an http.ServeMux:
+//
+//	mux.Handle("/swagger/", handler)
+//
+// The mount prefix is the part of the path before the served file name. Hooks receive
+// a *gin.Context created for the request, whose ClientIP honours forwarding headers
+// only from the TrustedProxies of the configuration. The internal gin engine creating
+// these contexts prints nothing, even in gin's debug mode.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.state.Load().httpEngine(h.serve).ServeHTTP(w, r)
+}
+
+// Config returns a copy of the current configuration.
+func (h *Handler) Config() Config {
+	return *h.state.Load().config
+}
+
+// Reconfigure applies options to the current configuration and re-renders the pages.
+// Requests in flight keep using the previous configuration.
+func (h *Handler) Reconfigure(options ...func(*Config)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	config := h.Config()
+	for _, option := range options {
+		option(&config)
+	}
+
+	h.state.Store(newHandlerState(config, h.assets))
+}
+
+func newHandlerState(config Config, assets fs.FS) *handlerState {
+	if config.InstanceName == "" {
+		config.InstanceName = swag.Name
+	}
+
+	if config.Title == "" {
+		config.Title = "Swagger UI"
+	}
+
+	renderer := config.Renderer
+	if renderer == nil {
+		renderer = newSwaggerUIRenderer(assets)
+	}
+
+	h := &handlerState{
+		config:       &config,
+		renderer:     renderer,
+		matcher:      newMatcher(renderer),
+		source:       config.docSource(),
+		transformers: config.docTransformers(),
+		pages:        map[string]renderedPage{},
+		openAPI3:     convertedDoc{convert: toOpenAPI3},
+		trusted:      parseTrustedProxies(config.TrustedProxies),
+	}
+
+	if config.SecurityHeaders != nil {
+		h.policy = config.contentSecurityPolicy(renderer)
+	}
+
+	for _, name := range renderer.Pages() {
+		body, err := renderer.Render(name, h.config)
+		h.pages[name] = renderedPage{body: body, version: h.versions.get(name, body), err: err}
+	}
+
+	if assets := renderer.Assets(); assets != nil {
+		h.assets = http.FS(assets)
+
+		if config.Precompress {
+			h.compressed = &precompressor{assets: assets}
+		}
+	}
+
+	return h
+}
+
+// httpEngine returns the engine running serve for every request, whatever its path and method.
+func (h *handlerState) httpEngine(serve gin.HandlerFunc) *gin.Engine {
+	h.engineOnce.Do(func() {
+		h.engine = newQuietEngine()
+		_ = h.engine.SetTrustedProxies(h.config.TrustedProxies)
+
+		h.engine.NoRoute(func(ctx *gin.Context) {
+			// gin answers unrouted requests with 404 unless the handler writes a status.
+			ctx.Status(http.StatusOK)
+			serve(ctx)
+		})
+	})
+
+	return h.engine
+}
+
+// quietEngineMu serializes the creation of internal engines, which swaps gin.DefaultWriter.
+var quietEngineMu sync.Mutex
+
+// newQuietEngine returns gin.New() without the debug mode warning gin.New prints to
+// gin.DefaultWriter: the engine is internal, and programs serving the documentation with
+// net/http need not know about gin. Other gin debug output is left alone.
+func newQuietEngine() *gin.Engine {
+	if !gin.IsDebugging() {
+		return gin.New()
+	}
+
+	quietEngineMu.Lock()
+	defer quietEngineMu.Unlock()
+
+	writer := gin.DefaultWriter
+	gin.DefaultWriter = io.Discard
+
+	defer func() { gin.DefaultWriter = writer }()
+
+	return gin.New()
+}
+
+// renderErr returns the first error met rendering the pages.
+func (h *handlerState) renderErr() error {
+	for _, name := range h.renderer.Pages() {
+		if err := h.pages[name].err; err != nil {
+			return fmt.Errorf("ginSwagger: rendering %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) serve(ctx *gin.Context) {
+	h.state.Load().serve(ctx)
+}
+
+func (h *handlerState) serve(ctx *gin.Context) {
+	if !h.config.enabled(ctx) {
+		return
+	}
+
+	if h.config.SecurityHeaders != nil {
+		h.config.SecurityHeaders.setSecurityHeaders(ctx)
+	}
+
+	_, path, ok := h.matcher.match(ctx)
+	ok = ok && h.serves(path)
+
+	if h.config.CORS != nil && ok && h.matcher.docs.MatchString(path) && h.config.CORS.setCORSHeaders(ctx) {
+		return
+	}
+
+	// Preflights carry no credentials, so OPTIONS is answered before the guards run,
+	// for the served files and the mount root only.
+	if ctx.Request.Method == http.MethodOptions && !ok && !isMountRoot(ctx) {
+		ctx.String(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+
+		return
+	}
+
+	if answerOptions(ctx) || !h.config.admit(ctx, h.trusted) || !allowedMethod(ctx) {
+		return
+	}
+
+	if !ok && isMountRoot(ctx) {
+		h.redirectIndex(ctx)
+
+		return
+	}
+
+	if !ok {
+		ctx.String(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+
+		return
+	}
+
+	switch filepath.Ext(path) {
+	case ".html":
+		ctx.Header("Content-Type", "text/html; charset=utf-8")
+	case ".css":
+		ctx.Header("Content-Type", "text/css; charset=utf-8")
+	case ".js":
+		ctx.Header("Content-Type", "application/javascript")
+	case ".png":
+		ctx.Header("Content-Type", "image/png")
+	case ".json":
+		ctx.Header("Content-Type", "application/json; charset=utf-8")
+	case ".yaml", ".yml":
+		ctx.Header("Content-Type", "application/yaml; charset=utf-8")
+	}
+
+	if !h.matcher.generated.MatchString(path) {
+		setCacheControl(ctx, h.config.CacheControl.Assets)
+
+		if h.compressed == nil || !h.compressed.serve(ctx, path) {
+			ctx.FileFromFS(path, h.assets)
+		}
+
+		return
+	}
+
+	if path == preauthorizeFile && h.config.Preauthorize != nil {
+		servePreauthorized(ctx, h.config.Preauthorize)
+
+		return
+	}
+
+	if page, ok := h.pages[path]; ok {
+		if page.err != nil {
+			ctx.AbortWithStatus(http.StatusInternalServerError)
+
+			return
+		}
+
+		if h.config.SecurityHeaders != nil && filepath.Ext(path) == ".html" {
+			h.config.SecurityHeaders.serveWithNonce(ctx, path, page.body, h.policy)
+
+			return
+		}
+
+		serveContent(ctx, path, page.body, page.version, h.config.CacheControl.Pages)
+
+		return
+	}
+
+	body, err := h.doc(ctx, path)
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist), errors.Is(err, errDisabled):
+		ctx.String(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	case err != nil:
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+	default:
+		serveContent(ctx, path, body, h.versions.get(path, body), h.config.CacheControl.Docs)
+	}
+}
+
+// serves reports whether the handler serves path, a file matched by the matcher, with its
+// configuration: generated endpoints that are turned off or not configured are not served.
+func (h *handlerState) serves(path string) bool {
+	if !h.matcher.generated.MatchString(path) {
+		return true
+	}
+
+	switch {
+	case path == preauthorizeFile:
+		return h.config.Preauthorize != nil
+	case path == "openapi.json":
+		return h.config.OpenAPI3
+	case strings.HasPrefix(path, specsDir):
+		_, ok := findSpec(h.config.Specs, path)
+
+		return ok
+	}
+
+	return true
+}
+
+// allowedMethods lists the methods answered by the handlers, sent in the Allow header.
+const allowedMethods = "GET, HEAD, OPTIONS"
+
+// answerOptions answers OPTIONS requests with the allowed methods, reporting whether it did.
+func answerOptions(ctx *gin.Context) bool {
+	if ctx.Request.Method != http.MethodOptions {
+		return false
+	}
+
+	ctx.Header("Allow", allowedMethods)
+	ctx.AbortWithStatus(http.StatusNoContent)
+
+	return true
+}
+
+// allowedMethod reports whether the request is a GET or HEAD, rejecting other methods with 405.
+// HEAD requests get the headers of GET without the body, as http.ServeContent writes no body for them.
+func allowedMethod(ctx *gin.Context) bool {
+	if ctx.Request.Method == http.MethodGet || ctx.Request.Method == http.MethodHead {
+		return true
+	}
+
+	ctx.Header("Allow", allowedMethods)
+	ctx.AbortWithStatus(http.StatusMethodNotAllowed)
+
+	return false
+}
+
+// redirectIndex redirects a request for the mount root to index.html, keeping its query.
+// The location is relative to the request URL, so it resolves against the URL the client
+// sees; behind a trusted proxy sending X-Forwarded-Prefix, it is the prefixed absolute path.
+func (h *handlerState) redirectIndex(ctx *gin.Context) {
+	urlPath := ctx.Request.URL.Path
+
+	var location string
+
+	ctx.Writer.Header().Add("Vary", "X-Forwarded-Prefix")
+
+	switch prefix := resolveForwarded(ctx, h.trusted).prefix; {
+	case prefix != "":
+		location = prefix + strings.TrimSuffix(urlPath, "/") + "/index.html"
+	case strings.HasSuffix(urlPath, "/"):
+		location = "index.html"
+	default:
+		location = path.Base(urlPath) + "/index.html"
+	}
+
+	if ctx.Request.URL.RawQuery != "" {
+		location += "?" + ctx.Request.URL.RawQuery
+	}
+
+	status := h.config.RedirectStatus
+	if status == 0 {
+		status = http.StatusFound
+	}
+
+	ctx.Header("Location", location)
+	ctx.AbortWithStatus(status)
+}
+
+// doc returns the document served at path, or fs.ErrNotExist if path is not a document endpoint.
+func (h *handlerState) doc(ctx *gin.Context, path string) ([]byte, error) {
+	if instanceName, ok := findSpec(h.config.Specs, path); ok {
+		doc, err := readDoc(ctx, instanceSource(instanceName), h.transformers)
+
+		return []byte(doc), err
+	}
+
+	switch path {
+	case "doc.json":
+		doc, err := readDoc(ctx, h.source, h.transformers)
+
+		return []byte(doc), err
+	case "doc.yaml", "doc.yml":
+		doc, err := readDoc(ctx, h.source, h.transformers)
+		if err != nil {
+			return nil, err
+		}
+
+		return jsonToYAML(doc)
+	case "openapi.json":
+		if !h.config.OpenAPI3 {
+			return nil, errDisabled
+		}
+
+		// Transformers may depend on the request, so their output is never cached.
+		if len(h.transformers) == 0 {
+			return h.openAPI3.read(h.source)
+		}
+
+		doc, err := readDoc(ctx, h.source, h.transformers)
+		if err != nil {
+			return nil, err
+		}
+
+		return toOpenAPI3(doc)
+	}
+
+	return nil, fs.ErrNotExist
+}
@@ -0,0 +1,238 @@
+package ginSwagger
+
+import (
+	"bytes"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	swaggerFilesV2 "github.com/swaggo/files/v2"
+	"golang.org/x/net/webdav"
+)
+
+// netHTTPAdapter is set while forEachAdapter runs a test with the net/http adapter.
+var netHTTPAdapter bool
+
+// forEachAdapter runs test as a subtest per adapter: with the gin.HandlerFunc of the handler,
+// and with the handler served through ServeHTTP, as by any net/http router.
+func forEachAdapter(t *testing.T, test func(t *testing.T)) {
+	for _, adapter := range []string{"gin", "net/http"} {
+		t.Run(adapter, func(t *testing.T) {
+			netHTTPAdapter = adapter == "net/http"
+			defer func() { netHTTPAdapter = false }()
+
+			test(t)
+		})
+	}
+}
+
+// adapt returns the gin.HandlerFunc of handler, or handler wrapped as an http.Handler
+// when the tests run with the net/http adapter.
+func adapt(handler *Handler) gin.HandlerFunc {
+	if netHTTPAdapter {
+		return gin.WrapH(handler)
+	}
+
+	return handler.HandlerFunc()
+}
+
+// The functions below mount the documentation like their exported counterparts,
+// with the adapter the tests run with.
+
+func wrapHandler(handler *webdav.Handler, options ...func(*Config)) gin.HandlerFunc {
+	return wrapFS(webdavAssets(handler), options...)
+}
+
+func wrapFS(assets fs.FS, options ...func(*Config)) gin.HandlerFunc {
+	if netHTTPAdapter {
+		return gin.WrapH(HTTPHandler(assets, options...))
+	}
+
+	return WrapFS(assets, options...)
+}
+
+func customWrapHandler(config *Config, handler *webdav.Handler) gin.HandlerFunc {
+	return customWrapFS(config, webdavAssets(handler))
+}
+
+func customWrapFS(config *Config, assets fs.FS) gin.HandlerFunc {
+	if netHTTPAdapter {
+		return adapt(NewHandler(config, assets))
+	}
+
+	return CustomWrapFS(config, assets)
+}
+
+func disablingWrapHandler(handler *webdav.Handler, envName string) gin.HandlerFunc {
+	if netHTTPAdapter {
+		return wrapHandler(handler, Enabled(EnvDisabled(envName)))
+	}
+
+	return DisablingWrapHandler(handler, envName)
+}
+
+func disablingCustomWrapHandler(config *Config, handler *webdav.Handler, envName string) gin.HandlerFunc {
+	if netHTTPAdapter {
+		disablingConfig := *config
+		disablingConfig.Enabled = EnvDisabled(envName)
+
+		return customWrapHandler(&disablingConfig, handler)
+	}
+
+	return DisablingCustomWrapHandler(config, handler, envName)
+}
+
+// mount serves the documentation built from options at prefix on a gin router.
+func mount(prefix string, options ...func(*Config)) http.Handler {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Any(prefix+"*any", wrapFS(swaggerFilesV2.FS, options...))
+
+	return router
+}
+
+// adapterCase is one request and the expected response.
+type adapterCase struct {
+	method      string
+	target      string
+	headers     map[string]string
+	remoteAddr  string
+	status      int
+	contentType string
+	header      map[string]string
+	contains    []string
+	notContains []string
+}
+
+func (c adapterCase) run(t *testing.T, handler http.Handler) {
+	method := c.method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	r := httptest.NewRequest(method, c.target, nil)
+	if c.remoteAddr != "" {
+		r.RemoteAddr = c.remoteAddr
+	}
+
+	for key, value := range c.headers {
+		r.Header.Set(key, value)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, c.status, w.Code, c.target)
+
+	if c.contentType != "" {
+		assert.Equal(t, c.contentType, w.Header().Get("Content-Type"), c.target)
+	}
+
+	for key, value := range c.header {
+		assert.Equal(t, value, w.Header().Get(key), c.target+" "+key)
+	}
+
+	for _, s := range c.contains {
+		assert.Contains(t, w.Body.String(), s, c.target)
+	}
+
+	for _, s := range c.notContains {
+		assert.NotContains(t, w.Body.String(), s, c.target)
+	}
+}
+
+func TestHTTPHandlerServeMux(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/admin/docs/", HTTPHandler(swaggerFilesV2.FS, InstanceName("validate"), OpenAPI3(true)))
+
+	adapterCase{target: "/admin/docs/index.html", status: http.StatusOK, contentType: "text/html; charset=utf-8"}.run(t, mux)
+	adapterCase{target: "/admin/docs/openapi.json", status: http.StatusOK, contains: []string{`"openapi":"3.0.3"`}}.run(t, mux)
+	adapterCase{target: "/admin/docs/", status: http.StatusFound, header: map[string]string{"Location": "index.html"}}.run(t, mux)
+}
+
+func TestHead(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.Any("/swagger/*any", wrapFS(swaggerFilesV2.FS, InstanceName("validate"), Precompress(true)))
+
+		for _, target := range []string{"/swagger/index.html", "/swagger/doc.json", "/swagger/doc.yaml", "/swagger/swagger-ui.css"} {
+			for _, encoding := range []string{"", "gzip"} {
+				get := httptest.NewRequest(http.MethodGet, target, nil)
+				head := httptest.NewRequest(http.MethodHead, target, nil)
+				get.Header.Set("Accept-Encoding", encoding)
+				head.Header.Set("Accept-Encoding", encoding)
+
+				w, h := httptest.NewRecorder(), httptest.NewRecorder()
+				router.ServeHTTP(w, get)
+				router.ServeHTTP(h, head)
+
+				assert.Equal(t, http.StatusOK, h.Code, target)
+				assert.Empty(t, h.Body.String(), target)
+				assert.NotEmpty(t, h.Header().Get("Content-Length"), target)
+
+				for _, header := range []string{"Content-Type", "Content-Length", "Content-Encoding", "ETag", "Last-Modified"} {
+					assert.Equal(t, w.Header().Get(header), h.Header().Get(header), target+" "+header)
+				}
+			}
+		}
+	})
+}
+
+func TestHTTPHandlerStripPrefix(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/docs/", http.StripPrefix("/docs", HTTPHandler(swaggerFilesV2.FS, InstanceName("validate"))))
+
+	adapterCase{target: "/docs/index.html", status: http.StatusOK}.run(t, mux)
+	adapterCase{target: "/docs/doc.json", status: http.StatusOK}.run(t, mux)
+}
+
+func TestHTTPHandlerQuiet(t *testing.T) {
+	var out bytes.Buffer
+
+	writer := gin.DefaultWriter
+	gin.SetMode(gin.DebugMode)
+	gin.DefaultWriter = &out
+
+	defer func() {
+		gin.SetMode(gin.TestMode)
+		gin.DefaultWriter = writer
+	}()
+
+	adapterCase{target: "/index.html", status: http.StatusOK}.run(t, HTTPHandler(swaggerFilesV2.FS, InstanceName("validate")))
+	assert.Empty(t, out.String())
+	assert.Equal(t, &out, gin.DefaultWriter)
+}
+
+func TestHandlerServeHTTPHooks(t *testing.T) {
+	var clientIP, path string
+
+	handler := NewHandler(&Config{
+		URL:            "doc.json",
+		InstanceName:   "validate",
+		TrustedProxies: []string{"127.0.0.1"},
+		Guards: []Guard{func(ctx *gin.Context) bool {
+			clientIP, path = ctx.ClientIP(), ctx.Request.URL.Path
+
+			return true
+		}},
+	}, swaggerFilesV2.FS)
+
+	adapterCase{target: "/doc.json", remoteAddr: "127.0.0.1:1234", headers: map[string]string{"X-Forwarded-For": "10.0.0.9"}, status: http.StatusOK}.run(t, handler)
+	assert.Equal(t, "10.0.0.9", clientIP)
+	assert.Equal(t, "/doc.json", path)
+
+	adapterCase{target: "/doc.json", remoteAddr: "192.168.0.1:1234", headers: map[string]string{"X-Forwarded-For": "10.0.0.9"}, status: http.StatusOK}.run(t, handler)
+	assert.Equal(t, "192.168.0.1", clientIP)
+
+	// Reconfiguring builds a new engine with the new trusted proxies.
+	handler.Reconfigure(func(c *Config) { c.TrustedProxies = nil })
+
+	adapterCase{target: "/doc.json", remoteAddr: "127.0.0.1:1234", headers: map[string]string{"X-Forwarded-For": "10.0.0.9"}, status: http.StatusOK}.run(t, handler)
+	assert.Equal(t, "127.0.0.1", clientIP)
+}
@@ -0,0 +1,419 @@
+package ginSwagger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/swaggo/swag"
+)
+
+// Kinds of names that can conflict when merging documents.
+const (
+	ConflictPath               = "path"
+	ConflictDefinition         = "definition"
+	ConflictSecurityDefinition = "securityDefinition"
+)
+
+// MergeConflict describes a path, definition or security definition declared differently
+// by several instances.
+type MergeConflict struct {
+	// Kind is ConflictPath, ConflictDefinition or ConflictSecurityDefinition.
+	Kind string
+	// Name of the conflicting path, definition or security definition.
+	Name string
+	// Instances declaring the name, in merge order.
+	Instances []string
+}
+
+// MergeConflictError is returned by MergeDocs when documents conflict and no Renamer is set.
+type MergeConflictError struct {
+	Conflicts []MergeConflict
+}
+
+func (e *MergeConflictError) Error() string {
+	conflicts := make([]string, 0, len(e.Conflicts))
+	for _, conflict := range e.Conflicts {
+		conflicts = append(conflicts, fmt.Sprintf("%s %q declared by %s",
+			conflict.Kind, conflict.Name, strings.Join(conflict.Instances, ", ")))
+	}
+
+	return "ginSwagger: conflicting documents: " + strings.Join(conflicts, "; ")
+}
+
+// Renamer returns the new name of a conflicting path, definition or security definition of the
+// given instance. kind is ConflictPath, ConflictDefinition or ConflictSecurityDefinition.
+type Renamer func(instanceName, kind, name string) string
+
+// PrefixRenamer resolves conflicts by prefixing paths with /<instanceName>
+// and definitions and security definitions with <instanceName>.
+func PrefixRenamer(instanceName, kind, name string) string {
+	if kind == ConflictPath {
+		return "/" + instanceName + name
+	}
+
+	return instanceName + "." + name
+}
+
+// mergeSource is a parsed document taking part in a merge.
+type mergeSource struct {
+	name string
+	doc  map[string]interface{}
+}
+
+// MergeDocs reads the given swag instances and merges their paths, definitions, tags and
+// securityDefinitions into one document. Info, host and schemes are taken from the first instance.
+// Identical declarations are merged silently; tags keep the first declaration.
+// Paths, definitions and securityDefinitions declared differently by several instances are renamed with renamer,
+// or reported as a *MergeConflictError when renamer is nil. The renamer may keep the name of
+// one instance; new names that are already declared are reported as errors. Paths that only
+// differ once references to renamed definitions are rewritten are renamed as well.
+func MergeDocs(renamer Renamer, instanceNames ...string) (string, error) {
+	sources := make([]mergeSource, 0, len(instanceNames))
+
+	for _, name := range instanceNames {
+		doc, err := swag.ReadDoc(name)
+		if err != nil {
+			return "", err
+		}
+
+		var spec map[string]interface{}
+		if err := json.Unmarshal([]byte(doc), &spec); err != nil {
+			return "", fmt.Errorf("ginSwagger: invalid document %q: %w", name, err)
+		}
+
+		sources = append(sources, mergeSource{name: name, doc: spec})
+	}
+
+	if len(sources) == 0 {
+		return "", errors.New("ginSwagger: no instances to merge")
+	}
+
+	foldBasePaths(sources)
+
+	conflicts := findConflicts(sources)
+	if len(conflicts) != 0 {
+		if renamer == nil {
+			return "", &MergeConflictError{Conflicts: conflicts}
+		}
+
+		if err := renameConflicts(sources, conflicts, renamer); err != nil {
+			return "", err
+		}
+
+		// Identical paths referring to renamed definitions differ once their references are rewritten.
+		if err := renameConflicts(sources, findConflicts(sources), renamer); err != nil {
+			return "", err
+		}
+	}
+
+	merged := map[string]interface{}{}
+	for key, value := range sources[0].doc {
+		merged[key] = value
+	}
+
+	paths := map[string]interface{}{}
+	definitions := map[string]interface{}{}
+	securityDefinitions := map[string]interface{}{}
+
+	var tags []interface{}
+
+	seenTags := map[string]bool{}
+
+	for _, source := range sources {
+		for path, item := range asMap(source.doc["paths"]) {
+			existing, ok := paths[path].(map[string]interface{})
+			if !ok {
+				paths[path] = item
+
+				continue
+			}
+
+			// Disjoint operations on the same path are combined.
+			for method, operation := range asMap(item) {
+				existing[method] = operation
+			}
+		}
+
+		for name, definition := range asMap(source.doc["definitions"]) {
+			definitions[name] = definition
+		}
+
+		for name, definition := range asMap(source.doc["securityDefinitions"]) {
+			securityDefinitions[name] = definition
+		}
+
+		list, _ := source.doc["tags"].([]interface{})
+		for _, tag := range list {
+			name, _ := asMap(tag)["name"].(string)
+			if seenTags[name] {
+				continue
+			}
+
+			seenTags[name] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	merged["paths"] = paths
+	setOrDelete(merged, "definitions", definitions)
+	setOrDelete(merged, "securityDefinitions", securityDefinitions)
+
+	if len(tags) != 0 {
+		merged["tags"] = tags
+	} else {
+		delete(merged, "tags")
+	}
+
+	body, err := json.Marshal(merged)
+	if err != nil {
+		return "", err
+	}
+
+	return string(body), nil
+}
+
+// foldBasePaths moves differing basePaths into the paths of each document,
+// so the merged document can use a single basePath.
+func foldBasePaths(sources []mergeSource) {
+	basePath, _ := sources[0].doc["basePath"].(string)
+	basePath = strings.TrimRight(basePath, "/")
+
+	same := true
+
+	for _, source := range sources[1:] {
+		if other, _ := source.doc["basePath"].(string); strings.TrimRight(other, "/") != basePath {
+			same = false
+
+			break
+		}
+	}
+
+	if same {
+		return
+	}
+
+	for _, source := range sources {
+		prefix, _ := source.doc["basePath"].(string)
+		prefix = strings.TrimRight(prefix, "/")
+
+		paths := map[string]interface{}{}
+		for path, item := range asMap(source.doc["paths"]) {
+			paths[prefix+path] = item
+		}
+
+		source.doc["paths"] = paths
+		delete(source.doc, "basePath")
+	}
+}
+
+// findConflicts returns the paths, definitions and security definitions declared differently
+// by several documents.
+func findConflicts(sources []mergeSource) []MergeConflict {
+	conflicts := append(
+		findDeclarationConflicts(sources, ConflictDefinition),
+		findDeclarationConflicts(sources, ConflictSecurityDefinition)...)
+
+	paths := map[string][]mergeSource{}
+
+	for _, source := range sources {
+		for path := range asMap(source.doc["paths"]) {
+			paths[path] = append(paths[path], source)
+		}
+	}
+
+	for _, path := range sortedKeys(paths) {
+		declared := paths[path]
+		if pathsConflict(path, declared) {
+			conflicts = append(conflicts, MergeConflict{Kind: ConflictPath, Name: path, Instances: sourceNames(declared)})
+		}
+	}
+
+	return conflicts
+}
+
+// findDeclarationConflicts returns the declarations of the given kind, such as definitions,
+// that differ between several documents.
+func findDeclarationConflicts(sources []mergeSource, kind string) []MergeConflict {
+	var conflicts []MergeConflict
+
+	section := conflictSections[kind]
+	declarations := map[string][]mergeSource{}
+
+	for _, source := range sources {
+		for name := range asMap(source.doc[section]) {
+			declarations[name] = append(declarations[name], source)
+		}
+	}
+
+	for _, name := range sortedKeys(declarations) {
+		declared := declarations[name]
+		for _, other := range declared[1:] {
+			if !reflect.DeepEqual(asMap(declared[0].doc[section])[name], asMap(other.doc[section])[name]) {
+				conflicts = append(conflicts, MergeConflict{Kind: kind, Name: name, Instances: sourceNames(declared)})
+
+				break
+			}
+		}
+	}
+
+	return conflicts
+}
+
+// pathsConflict reports whether several documents declare the same operation differently on a path.
+func pathsConflict(path string, declared []mergeSource) bool {
+	operations := map[string]interface{}{}
+
+	for _, source := range declared {
+		for method, operation := range asMap(asMap(source.doc["paths"])[path]) {
+			if existing, ok := operations[method]; ok && !reflect.DeepEqual(existing, operation) {
+				return true
+			}
+
+			operations[method] = operation
+		}
+	}
+
+	return false
+}
+
+// renameConflicts renames conflicting paths, definitions and security definitions in every
+// document declaring them, rewriting the references to renamed definitions and the security
+// requirements naming renamed security definitions. Names the renamer keeps are left alone, and
+// new names already declared by one of the documents are reported as errors.
+func renameConflicts(sources []mergeSource, conflicts []MergeConflict, renamer Renamer) error {
+	for _, conflict := range conflicts {
+		section := conflictSections[conflict.Kind]
+
+		for _, source := range sources {
+			if !containsString(conflict.Instances, source.name) {
+				continue
+			}
+
+			newName := renamer(source.name, conflict.Kind, conflict.Name)
+			if newName == conflict.Name {
+				continue
+			}
+
+			for _, other := range sources {
+				if _, ok := asMap(other.doc[section])[newName]; ok {
+					return fmt.Errorf("ginSwagger: renaming %s %q of %s: %q is already declared by %s",
+						conflict.Kind, conflict.Name, source.name, newName, other.name)
+				}
+			}
+
+			declarations := asMap(source.doc[section])
+			declarations[newName] = declarations[conflict.Name]
+			delete(declarations, conflict.Name)
+
+			switch conflict.Kind {
+			case ConflictDefinition:
+				renameRefs(source.doc, "#/definitions/"+conflict.Name, "#/definitions/"+newName)
+			case ConflictSecurityDefinition:
+				renameSecurityRequirements(source.doc, conflict.Name, newName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// conflictSections are the sections of the document holding each kind of conflicting name.
+var conflictSections = map[string]string{
+	ConflictPath:               "paths",
+	ConflictDefinition:         "definitions",
+	ConflictSecurityDefinition: "securityDefinitions",
+}
+
+// renameRefs rewrites every $ref equal to from into to.
+func renameRefs(node interface{}, from, to string) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if ref, ok := child.(string); ok && key == "$ref" && ref == from {
+				value[key] = to
+
+				continue
+			}
+
+			renameRefs(child, from, to)
+		}
+	case []interface{}:
+		for _, child := range value {
+			renameRefs(child, from, to)
+		}
+	}
+}
+
+// renameSecurityRequirements renames the security definition from into to in the security
+// requirements of the document and of its operations.
+func renameSecurityRequirements(doc map[string]interface{}, from, to string) {
+	rename := func(node interface{}) {
+		requirements, _ := node.([]interface{})
+		for _, requirement := range requirements {
+			if schemes := asMap(requirement); schemes != nil {
+				if scopes, ok := schemes[from]; ok {
+					schemes[to] = scopes
+					delete(schemes, from)
+				}
+			}
+		}
+	}
+
+	rename(doc["security"])
+
+	for _, item := range asMap(doc["paths"]) {
+		for _, operation := range asMap(item) {
+			rename(asMap(operation)["security"])
+		}
+	}
+}
+
+func asMap(node interface{}) map[string]interface{} {
+	value, _ := node.(map[string]interface{})
+
+	return value
+}
+
+func setOrDelete(doc map[string]interface{}, key string, value map[string]interface{}) {
+	if len(value) == 0 {
+		delete(doc, key)
+
+		return
+	}
+
+	doc[key] = value
+}
+
+func sortedKeys(m map[string][]mergeSource) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func sourceNames(sources []mergeSource) []string {
+	names := make([]string, 0, len(sources))
+	for _, source := range sources {
+		names = append(names, source.name)
+	}
+
+	return names
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,277 @@
+package ginSwagger
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	swaggerFiles "github.com/swaggo/files"
+	"github.com/swaggo/swag"
+)
+
+type rawSwag string
+
+func (s rawSwag) ReadDoc() string {
+	return string(s)
+}
+
+func init() {
+	swag.Register("merge_users", rawSwag(`{
+    "swagger": "2.0",
+    "info": {"title": "Users", "version": "1.0"},
+    "host": "api.example.com",
+    "basePath": "/api",
+    "tags": [{"name": "users"}, {"name": "shared"}],
+    "paths": {
+        "/users": {"get": {"security": [{"token": []}], "responses": {"200": {"description": "ok", "schema": {"$ref": "#/definitions/Item"}}}}},
+        "/health": {"get": {"responses": {"200": {"description": "ok"}}}}
+    },
+    "definitions": {
+        "Item": {"type": "object", "properties": {"name": {"type": "string"}}},
+        "Error": {"type": "object"}
+    },
+    "security": [{"token": []}],
+    "securityDefinitions": {"token": {"type": "apiKey", "name": "Authorization", "in": "header"}}
+}`))
+
+	swag.Register("merge_orders", rawSwag(`{
+    "swagger": "2.0",
+    "info": {"title": "Orders", "version": "2.0"},
+    "basePath": "/api",
+    "tags": [{"name": "orders"}, {"name": "shared", "description": "ignored"}],
+    "paths": {
+        "/orders": {"get": {"security": [{"token": []}], "responses": {"200": {"description": "ok", "schema": {"$ref": "#/definitions/Item"}}}}},
+        "/health": {"get": {"responses": {"200": {"description": "ok"}}}, "head": {"responses": {"200": {"description": "ok"}}}}
+    },
+    "definitions": {
+        "Item": {"type": "object", "properties": {"id": {"type": "integer"}}},
+        "Error": {"type": "object"}
+    },
+    "securityDefinitions": {"token": {"type": "apiKey", "name": "X-Token", "in": "header"}}
+}`))
+
+	swag.Register("merge_ref_a", rawSwag(`{
+    "swagger": "2.0",
+    "info": {"title": "A", "version": "1.0"},
+    "paths": {"/p": {"get": {"responses": {"200": {"description": "ok", "schema": {"$ref": "#/definitions/Foo"}}}}}},
+    "definitions": {"Foo": {"type": "string"}}
+}`))
+
+	swag.Register("merge_ref_b", rawSwag(`{
+    "swagger": "2.0",
+    "info": {"title": "B", "version": "1.0"},
+    "paths": {"/p": {"get": {"responses": {"200": {"description": "ok", "schema": {"$ref": "#/definitions/Foo"}}}}}},
+    "definitions": {"Foo": {"type": "integer"}}
+}`))
+
+	swag.Register("merge_legacy", rawSwag(`{
+    "swagger": "2.0",
+    "info": {"title": "Legacy", "version": "0.1"},
+    "basePath": "/legacy/",
+    "paths": {
+        "/users": {"get": {"responses": {"204": {"description": "legacy"}}}}
+    }
+}`))
+}
+
+func mergeDocs(t *testing.T, renamer Renamer, instanceNames ...string) map[string]interface{} {
+	body, err := MergeDocs(renamer, instanceNames...)
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(body), &doc))
+
+	return doc
+}
+
+func TestMergeDocsConflicts(t *testing.T) {
+	_, err := MergeDocs(nil, "merge_users", "merge_orders")
+
+	var conflictErr *MergeConflictError
+	require.True(t, errors.As(err, &conflictErr))
+	assert.Equal(t, []MergeConflict{
+		{Kind: ConflictDefinition, Name: "Item", Instances: []string{"merge_users", "merge_orders"}},
+		{Kind: ConflictSecurityDefinition, Name: "token", Instances: []string{"merge_users", "merge_orders"}},
+	}, conflictErr.Conflicts)
+	assert.Equal(t, `ginSwagger: conflicting documents: definition "Item" declared by merge_users, merge_orders; `+
+		`securityDefinition "token" declared by merge_users, merge_orders`, err.Error())
+
+	_, err = MergeDocs(nil)
+	assert.Error(t, err)
+
+	_, err = MergeDocs(nil, "merge_users", "merge_unknown")
+	assert.Error(t, err)
+}
+
+func TestMergeDocsPrefixRenamer(t *testing.T) {
+	doc := mergeDocs(t, PrefixRenamer, "merge_users", "merge_orders")
+
+	assert.Equal(t, map[string]interface{}{"title": "Users", "version": "1.0"}, doc["info"])
+	assert.Equal(t, "api.example.com", doc["host"])
+	assert.Equal(t, "/api", doc["basePath"])
+
+	definitions := doc["definitions"].(map[string]interface{})
+	assert.Contains(t, definitions, "merge_users.Item")
+	assert.Contains(t, definitions, "merge_orders.Item")
+	assert.Contains(t, definitions, "Error")
+	assert.NotContains(t, definitions, "Item")
+
+	paths := doc["paths"].(map[string]interface{})
+	assert.Contains(t, paths["/health"], "get")
+	assert.Contains(t, paths["/health"], "head")
+
+	users, _ := json.Marshal(paths["/users"])
+	assert.Contains(t, string(users), `"$ref":"#/definitions/merge_users.Item"`)
+
+	orders, _ := json.Marshal(paths["/orders"])
+	assert.Contains(t, string(orders), `"$ref":"#/definitions/merge_orders.Item"`)
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "users"},
+		map[string]interface{}{"name": "shared"},
+		map[string]interface{}{"name": "orders"},
+	}, doc["tags"])
+
+	security := doc["securityDefinitions"].(map[string]interface{})
+	assert.NotContains(t, security, "token")
+	assert.Equal(t, "Authorization", security["merge_users.token"].(map[string]interface{})["name"])
+	assert.Equal(t, "X-Token", security["merge_orders.token"].(map[string]interface{})["name"])
+
+	assert.Equal(t, []interface{}{map[string]interface{}{"merge_users.token": []interface{}{}}}, doc["security"])
+	assert.Contains(t, string(users), `"security":[{"merge_users.token":[]}]`)
+	assert.Contains(t, string(orders), `"security":[{"merge_orders.token":[]}]`)
+}
+
+func TestMergeDocsPathsReferringToRenamedDefinitions(t *testing.T) {
+	_, err := MergeDocs(nil, "merge_ref_a", "merge_ref_b")
+	assert.EqualError(t, err, `ginSwagger: conflicting documents: definition "Foo" declared by merge_ref_a, merge_ref_b`)
+
+	doc := mergeDocs(t, PrefixRenamer, "merge_ref_a", "merge_ref_b")
+
+	definitions := doc["definitions"].(map[string]interface{})
+	assert.Contains(t, definitions, "merge_ref_a.Foo")
+	assert.Contains(t, definitions, "merge_ref_b.Foo")
+
+	paths := doc["paths"].(map[string]interface{})
+	assert.NotContains(t, paths, "/p")
+
+	a, _ := json.Marshal(paths["/merge_ref_a/p"])
+	assert.Contains(t, string(a), `"$ref":"#/definitions/merge_ref_a.Foo"`)
+
+	b, _ := json.Marshal(paths["/merge_ref_b/p"])
+	assert.Contains(t, string(b), `"$ref":"#/definitions/merge_ref_b.Foo"`)
+}
+
+func TestMergeDocsBasePaths(t *testing.T) {
+	_, err := MergeDocs(nil, "merge_users", "merge_legacy")
+	assert.NoError(t, err)
+
+	doc := mergeDocs(t, nil, "merge_users", "merge_legacy")
+	assert.NotContains(t, doc, "basePath")
+
+	paths := doc["paths"].(map[string]interface{})
+	assert.Contains(t, paths, "/api/users")
+	assert.Contains(t, paths, "/api/health")
+	assert.Contains(t, paths, "/legacy/users")
+}
+
+func TestMergeDocsPathRenamer(t *testing.T) {
+	swag.Register("merge_users_copy", rawSwag(`{
+    "swagger": "2.0",
+    "basePath": "/api",
+    "paths": {"/users": {"get": {"responses": {"200": {"description": "copy"}}}}}
+}`))
+
+	renamer := func(instanceName, kind, name string) string {
+		assert.Equal(t, ConflictPath, kind)
+
+		return PrefixRenamer(instanceName, kind, name)
+	}
+
+	doc := mergeDocs(t, renamer, "merge_legacy", "merge_users_copy")
+	paths := doc["paths"].(map[string]interface{})
+	assert.Contains(t, paths, "/legacy/users")
+	assert.Contains(t, paths, "/api/users")
+
+	swag.Register("merge_legacy_copy", rawSwag(`{
+    "swagger": "2.0",
+    "basePath": "/legacy",
+    "paths": {"/users": {"get": {"responses": {"200": {"description": "copy"}}}}}
+}`))
+
+	doc = mergeDocs(t, renamer, "merge_legacy", "merge_legacy_copy")
+	paths = doc["paths"].(map[string]interface{})
+	assert.Contains(t, paths, "/merge_legacy/users")
+	assert.Contains(t, paths, "/merge_legacy_copy/users")
+}
+
+func TestMergeDocsRenamerKeepingNames(t *testing.T) {
+	keepFirst := func(instanceName, kind, name string) string {
+		if instanceName == "merge_users" {
+			return name
+		}
+
+		return PrefixRenamer(instanceName, kind, name)
+	}
+
+	doc := mergeDocs(t, keepFirst, "merge_users", "merge_orders")
+
+	definitions := doc["definitions"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"name": map[string]interface{}{"type": "string"}}, definitions["Item"].(map[string]interface{})["properties"])
+	assert.Contains(t, definitions, "merge_orders.Item")
+
+	paths := doc["paths"].(map[string]interface{})
+
+	users, _ := json.Marshal(paths["/users"])
+	assert.Contains(t, string(users), `"$ref":"#/definitions/Item"`)
+
+	orders, _ := json.Marshal(paths["/orders"])
+	assert.Contains(t, string(orders), `"$ref":"#/definitions/merge_orders.Item"`)
+}
+
+func TestMergeDocsRenamerCollisions(t *testing.T) {
+	toError := func(instanceName, kind, name string) string {
+		return "Error"
+	}
+
+	_, err := MergeDocs(toError, "merge_users", "merge_orders")
+	assert.EqualError(t, err, `ginSwagger: renaming definition "Item" of merge_users: "Error" is already declared by merge_users`)
+
+	same := func(instanceName, kind, name string) string {
+		return "Shared" + name
+	}
+
+	_, err = MergeDocs(same, "merge_users", "merge_orders")
+	assert.EqualError(t, err, `ginSwagger: renaming definition "Item" of merge_orders: "SharedItem" is already declared by merge_users`)
+}
+
+func TestMergeInstancesHandler(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/merged/*any", wrapHandler(swaggerFiles.Handler, MergeInstances(PrefixRenamer, "merge_users", "merge_orders")))
+		router.GET("/conflict/*any", wrapHandler(swaggerFiles.Handler, MergeInstances(nil, "merge_users", "merge_orders")))
+
+		w := performRequest(http.MethodGet, "/merged/doc.json", router)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"/orders"`)
+		assert.Contains(t, w.Body.String(), `"/users"`)
+
+		assert.Equal(t, http.StatusInternalServerError, performRequest(http.MethodGet, "/conflict/doc.json", router).Code)
+	})
+}
+
+func TestMergeInstances(t *testing.T) {
+	var cfg Config
+	assert.Empty(t, cfg.MergeInstances)
+
+	configFunc := MergeInstances(PrefixRenamer, "a", "b")
+	configFunc(&cfg)
+	assert.Equal(t, []string{"a", "b"}, cfg.MergeInstances)
+	assert.NotNil(t, cfg.MergeRenamer)
+}
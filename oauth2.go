@@ -0,0 +1,197 @@
+package ginSwagger
+
+import (
+	"bytes"
+	"encoding/json"
+	htmlTemplate "html/template"
+	"path"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Files of the OAuth2 redirect page, relative to the mount point.
+const (
+	oauth2RedirectPage   = "oauth2-redirect.html"
+	oauth2RedirectScript = "oauth2-redirect.js"
+)
+
+// OAuth2Config holds the settings Swagger UI passes to ui.initOAuth, prefilling
+// the OAuth2 Authorization dialog.
+type OAuth2Config struct {
+	// ClientID prefills the client_id field.
+	ClientID string `json:"clientId,omitempty"`
+	// ClientSecret prefills the client_secret field. Never set it outside development,
+	// as it is served to every visitor of the UI.
+	ClientSecret string `json:"clientSecret,omitempty"`
+	// Realm is appended to the authorization URL and the token URL as the realm query parameter.
+	Realm string `json:"realm,omitempty"`
+	// AppName is the application name shown in the dialog.
+	AppName string `json:"appName,omitempty"`
+	// Scopes are selected by default.
+	Scopes []string `json:"scopes,omitempty"`
+	// ScopeSeparator joins the scopes in requests. Defaults to a space.
+	ScopeSeparator string `json:"scopeSeparator,omitempty"`
+	// AdditionalQueryStringParams are added to the authorization URL, e.g. {"audience": "..."}.
+	AdditionalQueryStringParams map[string]string `json:"additionalQueryStringParams,omitempty"`
+	// UseBasicAuthenticationWithAccessCodeGrant sends the client credentials
+	// in an Authorization header instead of the request body of accessCode flows.
+	UseBasicAuthenticationWithAccessCodeGrant bool `json:"useBasicAuthenticationWithAccessCodeGrant,omitempty"`
+	// UsePkceWithAuthorizationCodeGrant enables Proof Key for Code Exchange in accessCode flows.
+	UsePkceWithAuthorizationCodeGrant bool `json:"usePkceWithAuthorizationCodeGrant,omitempty"`
+}
+
+// oauth2Config returns the settings passed to ui.initOAuth, or nil when initOAuth is not called.
+// Oauth2DefaultClientID and Oauth2UsePkce fill in the fields OAuth2 leaves unset.
+func (config Config) oauth2Config() *OAuth2Config {
+	var oauth2 OAuth2Config
+	if config.OAuth2 != nil {
+		oauth2 = *config.OAuth2
+	} else if config.Oauth2DefaultClientID == "" {
+		return nil
+	}
+
+	if oauth2.ClientID == "" {
+		oauth2.ClientID = config.Oauth2DefaultClientID
+	}
+
+	oauth2.UsePkceWithAuthorizationCodeGrant = oauth2.UsePkceWithAuthorizationCodeGrant || config.Oauth2UsePkce
+
+	return &oauth2
+}
+
+// initOAuthOptions renders the argument of ui.initOAuth as JSON, or null when it is not called.
+func (config Config) initOAuthOptions() string {
+	body, _ := json.Marshal(config.oauth2Config())
+
+	return string(body)
+}
+
+// oauth2RedirectURL returns the JavaScript expression of the oauth2RedirectUrl Swagger UI option.
+// Oauth2RedirectURL is resolved against the address of the UI, so relative URLs are allowed;
+// without it, the redirect page next to index.html is used.
+func (config Config) oauth2RedirectURL() string {
+	if config.Oauth2RedirectURL == "" {
+		return "`${window.location.protocol}//${window.location.host}$" +
+			"{window.location.pathname.split('/').slice(0, window.location.pathname.split('/').length - 1).join('/')}" +
+			"/" + oauth2RedirectPage + "`"
+	}
+
+	url, _ := json.Marshal(config.Oauth2RedirectURL)
+
+	return "new URL(" + string(url) + ", window.location.href).href"
+}
+
+// OAuth2RedirectHandler returns a `gin.HandlerFunc` serving the OAuth2 redirect page at a route
+// of its own, e.g. "/oauth2/*any" together with Oauth2RedirectURL("/oauth2/oauth2-redirect.html").
+// The page loads its script from oauth2-redirect.js next to it rather than inline, so it works
+// under a Content-Security-Policy allowing scripts from 'self'. Requests for oauth2-redirect.js
+// get the script and every other request the page; without a wildcard route, register the
+// handler for both paths.
+func OAuth2RedirectHandler() gin.HandlerFunc {
+	var (
+		versions contentVersions
+		page     bytes.Buffer
+	)
+
+	redirect := htmlTemplate.Must(htmlTemplate.New("oauth2_redirect.html").Parse(oauth2RedirectTpl))
+	_ = redirect.Execute(&page, swaggerConfig{})
+
+	return func(ctx *gin.Context) {
+		if answerOptions(ctx) || !allowedMethod(ctx) {
+			return
+		}
+
+		name, body := oauth2RedirectPage, page.Bytes()
+		if path.Base(ctx.Request.URL.Path) == oauth2RedirectScript {
+			name, body = oauth2RedirectScript, []byte(oauth2RedirectJS)
+
+			ctx.Header("Content-Type", "application/javascript")
+		} else {
+			ctx.Header("Content-Type", "text/html; charset=utf-8")
+		}
+
+		serveContent(ctx, name, body, versions.get(name, body), "")
+	}
+}
+
+const oauth2RedirectTpl = `<!doctype html>
+<html lang="en-US">
+<head>
+    <title>Swagger UI: OAuth2 Redirect</title>
+</head>
+<body>
+<script src="oauth2-redirect.js"{{if .Nonce}} nonce="{{.Nonce}}"{{end}}></script>
+</body>
+</html>
+`
+
+const oauth2RedirectJS = `'use strict';
+function run () {
+    var oauth2 = window.opener.swaggerUIRedirectOauth2;
+    var sentState = oauth2.state;
+    var redirectUrl = oauth2.redirectUrl;
+    var isValid, qp, arr;
+
+    if (/code|token|error/.test(window.location.hash)) {
+        qp = window.location.hash.substring(1).replace('?', '&');
+    } else {
+        qp = location.search.substring(1);
+    }
+
+    arr = qp.split("&");
+    arr.forEach(function (v,i,_arr) { _arr[i] = '"' + v.replace('=', '":"') + '"';});
+    qp = qp ? JSON.parse('{' + arr.join() + '}',
+            function (key, value) {
+                return key === "" ? value : decodeURIComponent(value);
+            }
+    ) : {};
+
+    isValid = qp.state === sentState;
+
+    if ((
+      oauth2.auth.schema.get("flow") === "accessCode" ||
+      oauth2.auth.schema.get("flow") === "authorizationCode" ||
+      oauth2.auth.schema.get("flow") === "authorization_code"
+    ) && !oauth2.auth.code) {
+        if (!isValid) {
+            oauth2.errCb({
+                authId: oauth2.auth.name,
+                source: "auth",
+                level: "warning",
+                message: "Authorization may be unsafe, passed state was changed in server. The passed state wasn't returned from auth server."
+            });
+        }
+
+        if (qp.code) {
+            delete oauth2.state;
+            oauth2.auth.code = qp.code;
+            oauth2.callback({auth: oauth2.auth, redirectUrl: redirectUrl});
+        } else {
+            let oauthErrorMsg;
+            if (qp.error) {
+                oauthErrorMsg = "["+qp.error+"]: " +
+                    (qp.error_description ? qp.error_description+ ". " : "no accessCode received from the server. ") +
+                    (qp.error_uri ? "More info: "+qp.error_uri : "");
+            }
+
+            oauth2.errCb({
+                authId: oauth2.auth.name,
+                source: "auth",
+                level: "error",
+                message: oauthErrorMsg || "[Authorization failed]: no accessCode received from the server."
+            });
+        }
+    } else {
+        oauth2.callback({auth: oauth2.auth, token: qp, isValid: isValid, redirectUrl: redirectUrl});
+    }
+    window.close();
+}
+
+if (document.readyState !== 'loading') {
+    run();
+} else {
+    document.addEventListener('DOMContentLoaded', function () {
+        run();
+    });
+}
+`
@@ -0,0 +1,198 @@
+package ginSwagger
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	swaggerFiles "github.com/swaggo/files"
+	swaggerFilesV2 "github.com/swaggo/files/v2"
+)
+
+// initOAuthArgument extracts the argument of ui.initOAuth from swagger-initializer.js, nil for null.
+func initOAuthArgument(t *testing.T, body string) map[string]interface{} {
+	const start = "const oauth2 = "
+
+	index := strings.Index(body, start)
+	require.NotEqual(t, -1, index)
+
+	var oauth2 map[string]interface{}
+	require.NoError(t, json.NewDecoder(strings.NewReader(body[index+len(start):])).Decode(&oauth2))
+
+	return oauth2
+}
+
+func oauth2Initializer(t *testing.T, options ...func(*Config)) map[string]interface{} {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.GET("/*any", wrapFS(swaggerFilesV2.FS, options...))
+
+	w := performRequest(http.MethodGet, "/swagger-initializer.js", router)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	return initOAuthArgument(t, w.Body.String())
+}
+
+func TestOAuth2NotConfigured(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		assert.Nil(t, oauth2Initializer(t))
+	})
+}
+
+func TestOAuth2Fields(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			config   OAuth2Config
+			expected map[string]interface{}
+		}{
+			"clientId": {
+				OAuth2Config{ClientID: "portal"},
+				map[string]interface{}{"clientId": "portal"},
+			},
+			"clientSecret": {
+				OAuth2Config{ClientSecret: "dev-secret"},
+				map[string]interface{}{"clientSecret": "dev-secret"},
+			},
+			"realm": {
+				OAuth2Config{Realm: "internal"},
+				map[string]interface{}{"realm": "internal"},
+			},
+			"appName": {
+				OAuth2Config{AppName: "API Portal"},
+				map[string]interface{}{"appName": "API Portal"},
+			},
+			"scopes": {
+				OAuth2Config{Scopes: []string{"openid", "read:pets"}},
+				map[string]interface{}{"scopes": []interface{}{"openid", "read:pets"}},
+			},
+			"scopeSeparator": {
+				OAuth2Config{ScopeSeparator: ","},
+				map[string]interface{}{"scopeSeparator": ","},
+			},
+			"additionalQueryStringParams": {
+				OAuth2Config{AdditionalQueryStringParams: map[string]string{"audience": "https://api.example.com"}},
+				map[string]interface{}{"additionalQueryStringParams": map[string]interface{}{"audience": "https://api.example.com"}},
+			},
+			"useBasicAuthenticationWithAccessCodeGrant": {
+				OAuth2Config{UseBasicAuthenticationWithAccessCodeGrant: true},
+				map[string]interface{}{"useBasicAuthenticationWithAccessCodeGrant": true},
+			},
+			"usePkceWithAuthorizationCodeGrant": {
+				OAuth2Config{UsePkceWithAuthorizationCodeGrant: true},
+				map[string]interface{}{"usePkceWithAuthorizationCodeGrant": true},
+			},
+		} {
+			assert.Equal(t, tc.expected, oauth2Initializer(t, OAuth2(tc.config)), name)
+		}
+	})
+}
+
+func TestOAuth2LegacyOptions(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		assert.Equal(t, map[string]interface{}{
+			"clientId":                          "legacy",
+			"usePkceWithAuthorizationCodeGrant": true,
+		}, oauth2Initializer(t, Oauth2DefaultClientID("legacy"), Oauth2UsePkce(true)))
+
+		// Without a client ID, Oauth2UsePkce alone never called initOAuth.
+		assert.Nil(t, oauth2Initializer(t, Oauth2UsePkce(true)))
+
+		assert.Equal(t, map[string]interface{}{
+			"clientId":                          "legacy",
+			"appName":                           "Portal",
+			"usePkceWithAuthorizationCodeGrant": true,
+		}, oauth2Initializer(t, Oauth2DefaultClientID("legacy"), Oauth2UsePkce(true), OAuth2(OAuth2Config{AppName: "Portal"})))
+
+		assert.Equal(t, map[string]interface{}{
+			"clientId": "portal",
+		}, oauth2Initializer(t, Oauth2DefaultClientID("legacy"), OAuth2(OAuth2Config{ClientID: "portal"})))
+	})
+}
+
+func TestOAuth2Escaping(t *testing.T) {
+	cfg := Config{OAuth2: &OAuth2Config{AppName: `"});alert(1);</script>`}}
+
+	assert.NotContains(t, cfg.initOAuthOptions(), "</script>")
+	assert.Equal(t, `{"appName":"\"});alert(1);\u003c/script\u003e"}`, cfg.initOAuthOptions())
+}
+
+func TestOAuth2(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.OAuth2)
+
+	configFunc := OAuth2(OAuth2Config{ClientID: "portal", Scopes: []string{"openid"}})
+	configFunc(&cfg)
+	assert.Equal(t, &OAuth2Config{ClientID: "portal", Scopes: []string{"openid"}}, cfg.OAuth2)
+}
+
+func TestOAuth2RedirectPage(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/swagger/*any", wrapHandler(swaggerFiles.Handler))
+
+		w := performRequest(http.MethodGet, "/swagger/oauth2-redirect.html", router)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), `<script src="oauth2-redirect.js"></script>`)
+		assert.NotContains(t, w.Body.String(), "<script>")
+
+		w = performRequest(http.MethodGet, "/swagger/oauth2-redirect.js", router)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/javascript", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "window.opener.swaggerUIRedirectOauth2")
+	})
+}
+
+func TestOAuth2RedirectURLOption(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		var cfg Config
+		assert.Contains(t, string(cfg.toSwaggerConfig().Oauth2RedirectURL), "/oauth2-redirect.html`")
+
+		configFunc := Oauth2RedirectURL("/auth/callback")
+		configFunc(&cfg)
+		assert.Equal(t, "/auth/callback", cfg.Oauth2RedirectURL)
+		assert.Equal(t, `new URL("/auth/callback", window.location.href).href`, string(cfg.toSwaggerConfig().Oauth2RedirectURL))
+
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/*any", wrapFS(swaggerFilesV2.FS, Oauth2RedirectURL("https://docs.example.com/oauth2/oauth2-redirect.html")))
+
+		w := performRequest(http.MethodGet, "/swagger-initializer.js", router)
+		assert.Contains(t, w.Body.String(), `oauth2RedirectUrl: new URL("https://docs.example.com/oauth2/oauth2-redirect.html", window.location.href).href,`)
+	})
+}
+
+func TestOAuth2RedirectHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.GET("/oauth2/*any", OAuth2RedirectHandler())
+	router.GET("/callback", OAuth2RedirectHandler())
+	router.GET("/oauth2-redirect.js", OAuth2RedirectHandler())
+	router.POST("/callback", OAuth2RedirectHandler())
+
+	for _, target := range []string{"/oauth2/oauth2-redirect.html", "/callback", "/callback?code=abc&state=xyz"} {
+		w := performRequest(http.MethodGet, target, router)
+		assert.Equal(t, http.StatusOK, w.Code, target)
+		assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"), target)
+		assert.Contains(t, w.Body.String(), `<script src="oauth2-redirect.js"></script>`, target)
+		assert.NotEmpty(t, w.Header().Get("ETag"), target)
+	}
+
+	for _, target := range []string{"/oauth2/oauth2-redirect.js", "/oauth2-redirect.js"} {
+		w := performRequest(http.MethodGet, target, router)
+		assert.Equal(t, http.StatusOK, w.Code, target)
+		assert.Equal(t, "application/javascript", w.Header().Get("Content-Type"), target)
+		assert.Contains(t, w.Body.String(), "window.opener.swaggerUIRedirectOauth2", target)
+	}
+
+	assert.Equal(t, http.StatusMethodNotAllowed, performRequest(http.MethodPost, "/callback", router).Code)
+}
@@ -0,0 +1,687 @@
+package ginSwagger
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// openAPI3Version is the OpenAPI version emitted by toOpenAPI3.
+const openAPI3Version = "3.0.3"
+
+// refPrefixes maps Swagger 2.0 reference prefixes to their OpenAPI 3 counterparts.
+var refPrefixes = map[string]string{
+	"#/definitions/":         "#/components/schemas/",
+	"#/parameters/":          "#/components/parameters/",
+	"#/responses/":           "#/components/responses/",
+	"#/securityDefinitions/": "#/components/securitySchemes/",
+}
+
+// Reference prefixes of global parameters in Swagger 2.0 and of request bodies in OpenAPI 3.
+const (
+	parametersRefPrefix    = "#/parameters/"
+	requestBodiesRefPrefix = "#/components/requestBodies/"
+)
+
+// schemaKeys are the Swagger 2.0 non-body parameter fields that belong to the schema in OpenAPI 3.
+var schemaKeys = []string{
+	"type", "format", "items", "collectionFormat", "default", "maximum", "exclusiveMaximum",
+	"minimum", "exclusiveMinimum", "maxLength", "minLength", "pattern", "maxItems", "minItems",
+	"uniqueItems", "enum", "multipleOf",
+}
+
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// toOpenAPI3 converts a Swagger 2.0 JSON document into an OpenAPI 3 JSON document.
+func toOpenAPI3(doc string) ([]byte, error) {
+	var spec map[string]interface{}
+	if err := json.Unmarshal([]byte(doc), &spec); err != nil {
+		return nil, err
+	}
+
+	if version, _ := spec["swagger"].(string); version != "2.0" {
+		return nil, errors.New("ginSwagger: only swagger 2.0 documents can be converted to openapi 3")
+	}
+
+	spec = rewriteRefs(spec, requestBodyParameters(spec)).(map[string]interface{})
+
+	out := map[string]interface{}{
+		"openapi": openAPI3Version,
+		"info":    spec["info"],
+		"paths":   map[string]interface{}{},
+	}
+
+	for key, value := range spec {
+		switch key {
+		case "tags", "security", "externalDocs":
+			out[key] = value
+		default:
+			if strings.HasPrefix(key, "x-") {
+				out[key] = value
+			}
+		}
+	}
+
+	if servers := convertServers(spec); len(servers) != 0 {
+		out["servers"] = servers
+	}
+
+	consumes := stringSlice(spec["consumes"])
+	produces := stringSlice(spec["produces"])
+
+	components := map[string]interface{}{}
+
+	if definitions, ok := spec["definitions"].(map[string]interface{}); ok {
+		schemas := make(map[string]interface{}, len(definitions))
+		for name, schema := range definitions {
+			schemas[name] = convertSchema(schema)
+		}
+
+		components["schemas"] = schemas
+	}
+
+	if parameters, ok := spec["parameters"].(map[string]interface{}); ok {
+		params := map[string]interface{}{}
+		bodies := map[string]interface{}{}
+
+		for name, param := range parameters {
+			p, _ := param.(map[string]interface{})
+			switch p["in"] {
+			case "body":
+				bodies[name] = convertBody(p, consumes)
+			case "formData":
+				bodies[name] = convertFormData([]map[string]interface{}{p}, consumes)
+			default:
+				params[name] = convertParameter(p)
+			}
+		}
+
+		if len(params) != 0 {
+			components["parameters"] = params
+		}
+
+		if len(bodies) != 0 {
+			components["requestBodies"] = bodies
+		}
+	}
+
+	if responses, ok := spec["responses"].(map[string]interface{}); ok {
+		converted := make(map[string]interface{}, len(responses))
+		for name, response := range responses {
+			converted[name] = convertResponse(response, produces)
+		}
+
+		components["responses"] = converted
+	}
+
+	if definitions, ok := spec["securityDefinitions"].(map[string]interface{}); ok {
+		schemes := make(map[string]interface{}, len(definitions))
+		for name, definition := range definitions {
+			schemes[name] = convertSecurityScheme(definition)
+		}
+
+		components["securitySchemes"] = schemes
+	}
+
+	if len(components) != 0 {
+		out["components"] = components
+	}
+
+	if paths, ok := spec["paths"].(map[string]interface{}); ok {
+		converted := make(map[string]interface{}, len(paths))
+		for path, item := range paths {
+			converted[path] = convertPathItem(item, consumes, produces)
+		}
+
+		out["paths"] = converted
+	}
+
+	return json.Marshal(out)
+}
+
+// convertedDoc converts a document on first use and caches the result.
+type convertedDoc struct {
+	mu      sync.Mutex
+	body    []byte
+	convert func(doc string) ([]byte, error)
+}
+
+// read returns the cached conversion of the source document, converting it if needed.
+// Failures are not cached, so a document registered later is still picked up.
+func (d *convertedDoc) read(source docSource) ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.body != nil {
+		return d.body, nil
+	}
+
+	doc, err := source()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := d.convert(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	d.body = body
+
+	return body, nil
+}
+
+// requestBodyParameters returns the names of the global body and formData parameters,
+// which become request bodies in OpenAPI 3.
+func requestBodyParameters(spec map[string]interface{}) map[string]bool {
+	names := map[string]bool{}
+
+	parameters, _ := spec["parameters"].(map[string]interface{})
+	for name, param := range parameters {
+		p, _ := param.(map[string]interface{})
+		if in := p["in"]; in == "body" || in == "formData" {
+			names[name] = true
+		}
+	}
+
+	return names
+}
+
+// rewriteRefs walks the document and rewrites every $ref to the OpenAPI 3 component location.
+// References to the global parameters in requestBodies point to the request bodies.
+func rewriteRefs(node interface{}, requestBodies map[string]bool) interface{} {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				if name := strings.TrimPrefix(ref, parametersRefPrefix); name != ref && requestBodies[name] {
+					value[key] = requestBodiesRefPrefix + name
+
+					continue
+				}
+
+				for from, to := range refPrefixes {
+					if strings.HasPrefix(ref, from) {
+						value[key] = to + strings.TrimPrefix(ref, from)
+
+						break
+					}
+				}
+
+				continue
+			}
+
+			value[key] = rewriteRefs(child, requestBodies)
+		}
+	case []interface{}:
+		for i, child := range value {
+			value[i] = rewriteRefs(child, requestBodies)
+		}
+	}
+
+	return node
+}
+
+// convertServers builds the servers list from host, basePath and schemes.
+func convertServers(spec map[string]interface{}) []interface{} {
+	host, _ := spec["host"].(string)
+	basePath, _ := spec["basePath"].(string)
+
+	if host == "" {
+		if basePath == "" {
+			return nil
+		}
+
+		return []interface{}{map[string]interface{}{"url": basePath}}
+	}
+
+	schemes := stringSlice(spec["schemes"])
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+
+	servers := make([]interface{}, 0, len(schemes))
+	for _, scheme := range schemes {
+		servers = append(servers, map[string]interface{}{"url": scheme + "://" + host + basePath})
+	}
+
+	return servers
+}
+
+func convertPathItem(node interface{}, consumes, produces []string) interface{} {
+	item, ok := node.(map[string]interface{})
+	if !ok {
+		return node
+	}
+
+	out := map[string]interface{}{}
+
+	for key, value := range item {
+		if key == "parameters" || isHTTPMethod(key) {
+			continue
+		}
+
+		out[key] = value
+	}
+
+	// Path level body and formData parameters have no OpenAPI 3 equivalent,
+	// so they are pushed down into every operation.
+	var shared []interface{}
+
+	if params, ok := item["parameters"].([]interface{}); ok {
+		var plain []interface{}
+
+		for _, param := range params {
+			p, _ := param.(map[string]interface{})
+			if in := p["in"]; in == "body" || in == "formData" || isRequestBodyRef(p) {
+				shared = append(shared, param)
+
+				continue
+			}
+
+			plain = append(plain, convertParameter(p))
+		}
+
+		if len(plain) != 0 {
+			out["parameters"] = plain
+		}
+	}
+
+	for _, method := range httpMethods {
+		if operation, ok := item[method].(map[string]interface{}); ok {
+			out[method] = convertOperation(operation, shared, consumes, produces)
+		}
+	}
+
+	return out
+}
+
+func convertOperation(operation map[string]interface{}, shared []interface{}, consumes, produces []string) map[string]interface{} {
+	out := map[string]interface{}{}
+
+	for key, value := range operation {
+		switch key {
+		case "consumes", "produces", "schemes", "parameters", "responses":
+		default:
+			out[key] = value
+		}
+	}
+
+	if c := stringSlice(operation["consumes"]); len(c) != 0 {
+		consumes = c
+	}
+
+	if p := stringSlice(operation["produces"]); len(p) != 0 {
+		produces = p
+	}
+
+	var (
+		params   []interface{}
+		body     map[string]interface{}
+		formData []map[string]interface{}
+	)
+
+	all := append([]interface{}{}, shared...)
+	if list, ok := operation["parameters"].([]interface{}); ok {
+		all = append(all, list...)
+	}
+
+	for _, param := range all {
+		p, _ := param.(map[string]interface{})
+		if isRequestBodyRef(p) {
+			body = p
+
+			continue
+		}
+
+		if ref, ok := p["$ref"].(string); ok && len(p) == 1 {
+			params = append(params, map[string]interface{}{"$ref": ref})
+
+			continue
+		}
+
+		switch p["in"] {
+		case "body":
+			body = p
+		case "formData":
+			formData = append(formData, p)
+		default:
+			params = append(params, convertParameter(p))
+		}
+	}
+
+	if len(params) != 0 {
+		out["parameters"] = params
+	}
+
+	switch {
+	case isRequestBodyRef(body):
+		out["requestBody"] = map[string]interface{}{"$ref": body["$ref"]}
+	case body != nil:
+		out["requestBody"] = convertBody(body, consumes)
+	case len(formData) != 0:
+		out["requestBody"] = convertFormData(formData, consumes)
+	}
+
+	if responses, ok := operation["responses"].(map[string]interface{}); ok {
+		converted := make(map[string]interface{}, len(responses))
+		for code, response := range responses {
+			converted[code] = convertResponse(response, produces)
+		}
+
+		out["responses"] = converted
+	}
+
+	return out
+}
+
+// isRequestBodyRef reports whether param is a reference to a global body or formData parameter.
+func isRequestBodyRef(param map[string]interface{}) bool {
+	ref, _ := param["$ref"].(string)
+
+	return strings.HasPrefix(ref, requestBodiesRefPrefix)
+}
+
+// convertParameter turns a query, header or path parameter into an OpenAPI 3 parameter.
+func convertParameter(param map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	schema := map[string]interface{}{}
+
+	for key, value := range param {
+		switch {
+		case key == "collectionFormat":
+			applyCollectionFormat(out, value)
+		case isSchemaKey(key):
+			schema[key] = value
+		default:
+			out[key] = value
+		}
+	}
+
+	if len(schema) != 0 {
+		out["schema"] = convertSchema(schema)
+	}
+
+	return out
+}
+
+// applyCollectionFormat maps a Swagger 2.0 collectionFormat onto style and explode.
+func applyCollectionFormat(param map[string]interface{}, format interface{}) {
+	switch format {
+	case "csv":
+		param["style"] = "form"
+		param["explode"] = false
+	case "ssv":
+		param["style"] = "spaceDelimited"
+	case "pipes":
+		param["style"] = "pipeDelimited"
+	case "multi":
+		param["style"] = "form"
+		param["explode"] = true
+	}
+}
+
+func convertBody(param map[string]interface{}, consumes []string) map[string]interface{} {
+	if len(consumes) == 0 {
+		consumes = []string{"application/json"}
+	}
+
+	content := make(map[string]interface{}, len(consumes))
+	for _, mediaType := range consumes {
+		content[mediaType] = map[string]interface{}{"schema": convertSchema(param["schema"])}
+	}
+
+	out := map[string]interface{}{"content": content}
+
+	if description, ok := param["description"]; ok {
+		out["description"] = description
+	}
+
+	if required, ok := param["required"]; ok {
+		out["required"] = required
+	}
+
+	return out
+}
+
+func convertFormData(params []map[string]interface{}, consumes []string) map[string]interface{} {
+	properties := map[string]interface{}{}
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+
+	var required []interface{}
+
+	mediaType := "application/x-www-form-urlencoded"
+
+	for _, param := range params {
+		name, _ := param["name"].(string)
+
+		property := map[string]interface{}{}
+		for key, value := range param {
+			if isSchemaKey(key) && key != "collectionFormat" {
+				property[key] = value
+			}
+		}
+
+		if description, ok := param["description"]; ok {
+			property["description"] = description
+		}
+
+		if property["type"] == "file" {
+			mediaType = "multipart/form-data"
+		}
+
+		properties[name] = convertSchema(property)
+
+		if isRequired, _ := param["required"].(bool); isRequired {
+			required = append(required, name)
+		}
+	}
+
+	if len(required) != 0 {
+		schema["required"] = required
+	}
+
+	for _, consume := range consumes {
+		if consume == "multipart/form-data" {
+			mediaType = consume
+		}
+	}
+
+	return map[string]interface{}{
+		"content": map[string]interface{}{
+			mediaType: map[string]interface{}{"schema": schema},
+		},
+	}
+}
+
+func convertResponse(node interface{}, produces []string) interface{} {
+	response, ok := node.(map[string]interface{})
+	if !ok {
+		return node
+	}
+
+	out := map[string]interface{}{}
+
+	for key, value := range response {
+		switch key {
+		case "schema", "examples":
+		case "headers":
+			headers, _ := value.(map[string]interface{})
+			converted := make(map[string]interface{}, len(headers))
+
+			for name, header := range headers {
+				h, _ := header.(map[string]interface{})
+				converted[name] = convertParameter(h)
+			}
+
+			out[key] = converted
+		default:
+			out[key] = value
+		}
+	}
+
+	// A bare reference is kept as-is, everything else needs a description in OpenAPI 3.
+	if _, ok := out["$ref"]; !ok {
+		if _, ok := out["description"]; !ok {
+			out["description"] = ""
+		}
+	}
+
+	if schema, ok := response["schema"]; ok {
+		if len(produces) == 0 {
+			produces = []string{"application/json"}
+		}
+
+		examples, _ := response["examples"].(map[string]interface{})
+		content := make(map[string]interface{}, len(produces))
+
+		for _, mediaType := range produces {
+			media := map[string]interface{}{"schema": convertSchema(schema)}
+			if example, ok := examples[mediaType]; ok {
+				media["example"] = example
+			}
+
+			content[mediaType] = media
+		}
+
+		out["content"] = content
+	}
+
+	return out
+}
+
+// convertSchema fixes up the schema keywords that changed between Swagger 2.0 and OpenAPI 3.
+func convertSchema(node interface{}) interface{} {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(value))
+
+		for key, child := range value {
+			switch key {
+			case "x-nullable":
+				out["nullable"] = child
+			case "discriminator":
+				if name, ok := child.(string); ok {
+					out[key] = map[string]interface{}{"propertyName": name}
+				} else {
+					out[key] = child
+				}
+			case "properties", "definitions":
+				props, _ := child.(map[string]interface{})
+				converted := make(map[string]interface{}, len(props))
+
+				for name, prop := range props {
+					converted[name] = convertSchema(prop)
+				}
+
+				out[key] = converted
+			case "enum", "required", "example", "default":
+				out[key] = child
+			default:
+				out[key] = convertSchema(child)
+			}
+		}
+
+		if out["type"] == "file" {
+			out["type"] = "string"
+			out["format"] = "binary"
+		}
+
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(value))
+		for i, child := range value {
+			out[i] = convertSchema(child)
+		}
+
+		return out
+	}
+
+	return node
+}
+
+func convertSecurityScheme(node interface{}) interface{} {
+	definition, ok := node.(map[string]interface{})
+	if !ok {
+		return node
+	}
+
+	out := map[string]interface{}{}
+
+	for key, value := range definition {
+		switch key {
+		case "type", "flow", "authorizationUrl", "tokenUrl", "scopes":
+		default:
+			out[key] = value
+		}
+	}
+
+	switch definition["type"] {
+	case "basic":
+		out["type"] = "http"
+		out["scheme"] = "basic"
+	case "oauth2":
+		out["type"] = "oauth2"
+
+		flow := map[string]interface{}{"scopes": map[string]interface{}{}}
+		if scopes, ok := definition["scopes"]; ok {
+			flow["scopes"] = scopes
+		}
+
+		if authorizationURL, ok := definition["authorizationUrl"]; ok {
+			flow["authorizationUrl"] = authorizationURL
+		}
+
+		if tokenURL, ok := definition["tokenUrl"]; ok {
+			flow["tokenUrl"] = tokenURL
+		}
+
+		name, _ := definition["flow"].(string)
+		switch name {
+		case "application":
+			name = "clientCredentials"
+		case "accessCode":
+			name = "authorizationCode"
+		}
+
+		out["flows"] = map[string]interface{}{name: flow}
+	default:
+		out["type"] = definition["type"]
+	}
+
+	return out
+}
+
+func isHTTPMethod(key string) bool {
+	for _, method := range httpMethods {
+		if key == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSchemaKey(key string) bool {
+	for _, schemaKey := range schemaKeys {
+		if key == schemaKey {
+			return true
+		}
+	}
+
+	return false
+}
+
+func stringSlice(node interface{}) []string {
+	list, _ := node.([]interface{})
+
+	out := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+
+	return out
+}
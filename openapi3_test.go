@@ -0,0 +1,291 @@
+package ginSwagger
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	swaggerFiles "github.com/swaggo/files"
+	"github.com/swaggo/swag"
+)
+
+const petstoreDoc = `{
+    "swagger": "2.0",
+    "info": {"title": "Petstore", "version": "1.0"},
+    "host": "petstore.swagger.io:8080",
+    "basePath": "/v2",
+    "schemes": ["http", "https"],
+    "consumes": ["application/json"],
+    "produces": ["application/json"],
+    "tags": [{"name": "pet"}],
+    "paths": {
+        "/pets/{id}": {
+            "parameters": [
+                {"name": "id", "in": "path", "required": true, "type": "integer", "format": "int64"}
+            ],
+            "get": {
+                "tags": ["pet"],
+                "parameters": [
+                    {"name": "fields", "in": "query", "type": "array", "items": {"type": "string"}, "collectionFormat": "csv"}
+                ],
+                "responses": {
+                    "200": {"description": "ok", "schema": {"$ref": "#/definitions/Pet"}},
+                    "404": {"description": "not found"}
+                }
+            },
+            "put": {
+                "parameters": [
+                    {"name": "pet", "in": "body", "required": true, "schema": {"$ref": "#/definitions/Pet"}}
+                ],
+                "responses": {"204": {"description": "updated"}}
+            }
+        },
+        "/pets/{id}/photo": {
+            "post": {
+                "consumes": ["multipart/form-data"],
+                "parameters": [
+                    {"name": "id", "in": "path", "required": true, "type": "integer"},
+                    {"name": "file", "in": "formData", "required": true, "type": "file"},
+                    {"name": "caption", "in": "formData", "type": "string"}
+                ],
+                "responses": {"200": {"description": "ok"}}
+            }
+        }
+    },
+    "definitions": {
+        "Pet": {
+            "type": "object",
+            "properties": {
+                "name": {"type": "string", "x-nullable": true},
+                "owner": {"$ref": "#/definitions/Owner"}
+            }
+        },
+        "Owner": {"type": "object"}
+    },
+    "securityDefinitions": {
+        "basic": {"type": "basic"},
+        "oauth": {"type": "oauth2", "flow": "accessCode", "authorizationUrl": "https://auth/authorize", "tokenUrl": "https://auth/token", "scopes": {"read": "read access"}}
+    }
+}`
+
+func convertPetstore(t *testing.T) map[string]interface{} {
+	body, err := toOpenAPI3(petstoreDoc)
+	require.NoError(t, err)
+
+	var out map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &out))
+
+	return out
+}
+
+func TestToOpenAPI3(t *testing.T) {
+	out := convertPetstore(t)
+
+	assert.Equal(t, openAPI3Version, out["openapi"])
+	assert.Equal(t, map[string]interface{}{"title": "Petstore", "version": "1.0"}, out["info"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "pet"}}, out["tags"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"url": "http://petstore.swagger.io:8080/v2"},
+		map[string]interface{}{"url": "https://petstore.swagger.io:8080/v2"},
+	}, out["servers"])
+
+	assert.NotContains(t, out, "swagger")
+	assert.NotContains(t, out, "definitions")
+	assert.NotContains(t, out, "host")
+}
+
+func TestToOpenAPI3Components(t *testing.T) {
+	components := convertPetstore(t)["components"].(map[string]interface{})
+
+	schemas := components["schemas"].(map[string]interface{})
+	pet := schemas["Pet"].(map[string]interface{})
+	properties := pet["properties"].(map[string]interface{})
+
+	assert.Equal(t, map[string]interface{}{"type": "string", "nullable": true}, properties["name"])
+	assert.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/Owner"}, properties["owner"])
+
+	schemes := components["securitySchemes"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "http", "scheme": "basic"}, schemes["basic"])
+	assert.Equal(t, map[string]interface{}{
+		"type": "oauth2",
+		"flows": map[string]interface{}{
+			"authorizationCode": map[string]interface{}{
+				"authorizationUrl": "https://auth/authorize",
+				"tokenUrl":         "https://auth/token",
+				"scopes":           map[string]interface{}{"read": "read access"},
+			},
+		},
+	}, schemes["oauth"])
+}
+
+func TestToOpenAPI3Operations(t *testing.T) {
+	paths := convertPetstore(t)["paths"].(map[string]interface{})
+	item := paths["/pets/{id}"].(map[string]interface{})
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name": "id", "in": "path", "required": true,
+			"schema": map[string]interface{}{"type": "integer", "format": "int64"},
+		},
+	}, item["parameters"])
+
+	get := item["get"].(map[string]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name": "fields", "in": "query", "style": "form", "explode": false,
+			"schema": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+		},
+	}, get["parameters"])
+
+	responses := get["responses"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"description": "ok",
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": map[string]interface{}{"$ref": "#/components/schemas/Pet"},
+			},
+		},
+	}, responses["200"])
+	assert.Equal(t, map[string]interface{}{"description": "not found"}, responses["404"])
+
+	put := item["put"].(map[string]interface{})
+	assert.NotContains(t, put, "parameters")
+	assert.Equal(t, map[string]interface{}{
+		"required": true,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": map[string]interface{}{"$ref": "#/components/schemas/Pet"},
+			},
+		},
+	}, put["requestBody"])
+
+	post := paths["/pets/{id}/photo"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Len(t, post["parameters"], 1)
+	assert.Equal(t, map[string]interface{}{
+		"content": map[string]interface{}{
+			"multipart/form-data": map[string]interface{}{
+				"schema": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"file":    map[string]interface{}{"type": "string", "format": "binary"},
+						"caption": map[string]interface{}{"type": "string"},
+					},
+					"required": []interface{}{"file"},
+				},
+			},
+		},
+	}, post["requestBody"])
+}
+
+func TestToOpenAPI3RequestBodyRefs(t *testing.T) {
+	body, err := toOpenAPI3(`{
+    "swagger": "2.0",
+    "info": {"title": "Refs", "version": "1.0"},
+    "parameters": {
+        "Pet": {"name": "pet", "in": "body", "required": true, "schema": {"type": "object"}},
+        "Caption": {"name": "caption", "in": "formData", "type": "string"},
+        "Limit": {"name": "limit", "in": "query", "type": "integer"}
+    },
+    "paths": {
+        "/pets": {
+            "parameters": [{"$ref": "#/parameters/Pet"}],
+            "post": {"parameters": [{"$ref": "#/parameters/Limit"}], "responses": {"201": {"description": "created"}}}
+        },
+        "/photos": {
+            "post": {"parameters": [{"$ref": "#/parameters/Caption"}], "responses": {"201": {"description": "created"}}}
+        }
+    }
+}`)
+	require.NoError(t, err)
+
+	var out map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &out))
+
+	components := out["components"].(map[string]interface{})
+	assert.Contains(t, components["requestBodies"], "Pet")
+	assert.Contains(t, components["requestBodies"], "Caption")
+	assert.Equal(t, map[string]interface{}{"Limit": map[string]interface{}{
+		"name": "limit", "in": "query", "schema": map[string]interface{}{"type": "integer"},
+	}}, components["parameters"])
+
+	paths := out["paths"].(map[string]interface{})
+
+	pets := paths["/pets"].(map[string]interface{})
+	assert.NotContains(t, pets, "parameters")
+
+	post := pets["post"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"$ref": "#/components/requestBodies/Pet"}, post["requestBody"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"$ref": "#/components/parameters/Limit"}}, post["parameters"])
+
+	photo := paths["/photos"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"$ref": "#/components/requestBodies/Caption"}, photo["requestBody"])
+	assert.NotContains(t, photo, "parameters")
+}
+
+func TestToOpenAPI3Invalid(t *testing.T) {
+	_, err := toOpenAPI3(`{"openapi": "3.0.0"}`)
+	assert.Error(t, err)
+
+	_, err = toOpenAPI3(`not json`)
+	assert.Error(t, err)
+}
+
+// petstoreInstance counts the reads of the "petstore" instance.
+var petstoreInstance = &petstoreSwag{}
+
+func init() {
+	swag.Register("petstore", petstoreInstance)
+}
+
+type petstoreSwag struct {
+	reads int
+}
+
+func (s *petstoreSwag) ReadDoc() string {
+	s.reads++
+
+	return petstoreDoc
+}
+
+func TestOpenAPI3Endpoint(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		doc := petstoreInstance
+		doc.reads = 0
+
+		router.GET("/enabled/*any", wrapHandler(swaggerFiles.Handler, InstanceName("petstore"), OpenAPI3(true)))
+		router.GET("/disabled/*any", wrapHandler(swaggerFiles.Handler, InstanceName("petstore")))
+
+		for i := 0; i < 2; i++ {
+			w := performRequest(http.MethodGet, "/enabled/openapi.json", router)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+			var out map[string]interface{}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &out))
+			assert.Equal(t, openAPI3Version, out["openapi"])
+		}
+
+		assert.Equal(t, 1, doc.reads)
+
+		assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/disabled/openapi.json", router).Code)
+	})
+}
+
+func TestOpenAPI3(t *testing.T) {
+	var cfg Config
+	assert.Equal(t, false, cfg.OpenAPI3)
+
+	configFunc := OpenAPI3(true)
+	configFunc(&cfg)
+	assert.Equal(t, true, cfg.OpenAPI3)
+
+	configFunc = OpenAPI3(false)
+	configFunc(&cfg)
+	assert.Equal(t, false, cfg.OpenAPI3)
+}
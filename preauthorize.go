@@ -0,0 +1,65 @@
+package ginSwagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// preauthorizeFile serves the credentials of the request as JSON. The initializer fetches it
+// rather than embedding them: a page of another origin may include scripts of ours with the
+// cookies of the user, but cannot read a JSON response without CORS.
+const preauthorizeFile = "preauthorize.json"
+
+// Credential is the value a security scheme is pre-authorized with.
+type Credential struct {
+	// APIKey is the value of an apiKey or bearer scheme, e.g. "Bearer <token>"
+	// for an apiKey scheme read from the Authorization header.
+	APIKey string `json:"apiKey,omitempty"`
+	// Username and Password of a basic scheme. Ignored when APIKey is set.
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// Preauthorizer returns the credentials the UI is pre-authorized with, keyed by the name of
+// the security scheme in the document. It runs for every request of preauthorize.json, which
+// the UI fetches once the document is loaded; returning no credentials leaves the UI unauthorized.
+type Preauthorizer func(ctx *gin.Context) (map[string]Credential, error)
+
+// servePreauthorized writes the credentials of the request as JSON. The response is personal,
+// so it is never cached nor answered with 304, and requests made on behalf of other sites,
+// as told by Sec-Fetch-Site, are refused.
+func servePreauthorized(ctx *gin.Context, preauthorizer Preauthorizer) {
+	switch ctx.GetHeader("Sec-Fetch-Site") {
+	case "", "same-origin", "none":
+	default:
+		ctx.AbortWithStatus(http.StatusForbidden)
+
+		return
+	}
+
+	credentials, err := preauthorizer(ctx)
+	if err != nil {
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+
+		return
+	}
+
+	if credentials == nil {
+		credentials = map[string]Credential{}
+	}
+
+	body, err := json.Marshal(credentials)
+	if err != nil {
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+
+	http.ServeContent(ctx.Writer, ctx.Request, preauthorizeFile, time.Time{}, bytes.NewReader(body))
+}
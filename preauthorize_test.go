@@ -0,0 +1,117 @@
+package ginSwagger
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	swaggerFilesV2 "github.com/swaggo/files/v2"
+)
+
+// sessionPreauthorizer pre-authorizes requests carrying a session cookie with its token.
+func sessionPreauthorizer(ctx *gin.Context) (map[string]Credential, error) {
+	session, err := ctx.Cookie("session")
+	if err != nil {
+		return nil, nil
+	}
+
+	if session == "broken" {
+		return nil, errors.New("session store unavailable")
+	}
+
+	return map[string]Credential{
+		"BearerAuth": {APIKey: "Bearer " + session},
+		"BasicAuth":  {Username: "portal", Password: `p"ss`},
+	}, nil
+}
+
+func TestPreauthorize(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/*any", wrapFS(swaggerFilesV2.FS, Preauthorize(sessionPreauthorizer), CacheControl(RecommendedCachePolicy)))
+
+		// The initializer fetches the credentials and is the same for every user.
+		initializer := performConditionalRequest(router, "/swagger-initializer.js", map[string]string{"Cookie": "session=abc"})
+		assert.Equal(t, http.StatusOK, initializer.Code)
+		assert.Equal(t, "no-cache", initializer.Header().Get("Cache-Control"))
+		assert.NotEmpty(t, initializer.Header().Get("ETag"))
+		assert.Contains(t, initializer.Body.String(), `fetch("preauthorize.json", {credentials: "same-origin"`)
+		assert.NotContains(t, initializer.Body.String(), "abc")
+		assert.NotContains(t, initializer.Body.String(), "window.swaggerUIPreauthorize")
+
+		anonymous := performRequest(http.MethodGet, "/preauthorize.json", router)
+		assert.Equal(t, http.StatusOK, anonymous.Code)
+		assert.Equal(t, "application/json; charset=utf-8", anonymous.Header().Get("Content-Type"))
+		assert.Equal(t, "no-store", anonymous.Header().Get("Cache-Control"))
+		assert.Empty(t, anonymous.Header().Get("ETag"))
+		assert.Equal(t, `{}`, anonymous.Body.String())
+
+		w := performConditionalRequest(router, "/preauthorize.json", map[string]string{"Cookie": "session=abc", "Sec-Fetch-Site": "same-origin"})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		assert.JSONEq(t, `{
+		"BasicAuth": {"username": "portal", "password": "p\"ss"},
+		"BearerAuth": {"apiKey": "Bearer abc"}
+	}`, w.Body.String())
+
+		other := performConditionalRequest(router, "/preauthorize.json", map[string]string{"Cookie": "session=xyz"})
+		assert.Contains(t, other.Body.String(), `"apiKey":"Bearer xyz"`)
+		assert.NotContains(t, other.Body.String(), "abc")
+
+		w = performConditionalRequest(router, "/preauthorize.json", map[string]string{"Cookie": "session=broken"})
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestPreauthorizeCrossSite(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/*any", wrapFS(swaggerFilesV2.FS, Preauthorize(sessionPreauthorizer), CORS(CORSPolicy{AllowOrigins: []string{"*"}})))
+
+		// Requests made on behalf of other sites, e.g. by a <script> or fetch of a foreign page, are refused.
+		for _, site := range []string{"cross-site", "same-site"} {
+			w := performConditionalRequest(router, "/preauthorize.json", map[string]string{
+				"Cookie":         "session=abc",
+				"Sec-Fetch-Site": site,
+				"Origin":         "https://evil.example",
+			})
+			assert.Equal(t, http.StatusForbidden, w.Code, site)
+			assert.NotContains(t, w.Body.String(), "abc", site)
+			assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"), site)
+		}
+
+		// Browsers without Sec-Fetch-Site still refuse to expose the response: it is JSON, not a
+		// script, and never carries CORS headers, not even with a CORS policy for the documents.
+		w := performConditionalRequest(router, "/preauthorize.json", map[string]string{"Cookie": "session=abc", "Origin": "https://evil.example"})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+		assert.NotContains(t, performRequest(http.MethodGet, "/swagger-initializer.js", router).Body.String(), "window.swaggerUIPreauthorize")
+	})
+}
+
+func TestWithoutPreauthorize(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/*any", wrapFS(swaggerFilesV2.FS))
+
+		assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/preauthorize.json", router).Code)
+		assert.NotContains(t, performRequest(http.MethodGet, "/swagger-initializer.js", router).Body.String(), "preauthorize.json")
+	})
+}
+
+func TestPreauthorizeOption(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.Preauthorize)
+
+	configFunc := Preauthorize(sessionPreauthorizer)
+	configFunc(&cfg)
+	assert.NotNil(t, cfg.Preauthorize)
+}
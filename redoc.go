@@ -0,0 +1,77 @@
+package ginSwagger
+
+import (
+	"bytes"
+	htmlTemplate "html/template"
+	"io/fs"
+)
+
+// redocRenderer renders ReDoc three-panel reference documentation.
+type redocRenderer struct {
+	assets fs.FS
+	index  *htmlTemplate.Template
+	err    error
+}
+
+// ReDoc returns a Renderer serving ReDoc instead of Swagger UI.
+// assets must contain redoc.standalone.js, e.g. an embed.FS with a vendored copy
+// of the ReDoc release, so no CDN is involved.
+func ReDoc(assets fs.FS) Renderer {
+	index, err := htmlTemplate.New("redoc_index.html").Parse(redocIndexTpl)
+
+	return &redocRenderer{assets: assets, index: index, err: err}
+}
+
+func (r *redocRenderer) Pages() []string {
+	return []string{"index.html"}
+}
+
+func (r *redocRenderer) Render(name string, config *Config) ([]byte, error) {
+	if name != "index.html" {
+		return nil, fs.ErrNotExist
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	var buf bytes.Buffer
+	err := r.index.Execute(&buf, config.toSwaggerConfig())
+
+	return buf.Bytes(), err
+}
+
+func (r *redocRenderer) Assets() fs.FS {
+	return r.assets
+}
+
+// PolicySources allows the styles ReDoc injects at runtime with styled-components,
+// which carry no nonce, and the search worker it starts from a blob.
+func (r *redocRenderer) PolicySources() map[string][]string {
+	return map[string][]string{
+		"style-src":  {"'unsafe-inline'"},
+		"worker-src": {"blob:"},
+	}
+}
+
+const redocIndexTpl = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>{{.Title}}</title>
+  <style{{if .Nonce}} nonce="{{.Nonce}}"{{end}}>
+    body {
+      margin: 0;
+      padding: 0;
+    }
+  </style>
+</head>
+
+<body>
+<redoc spec-url="{{.URL}}"></redoc>
+<script src="./redoc.standalone.js"{{if .Nonce}} nonce="{{.Nonce}}"{{end}}> </script>
+</body>
+
+</html>
+`
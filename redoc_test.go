@@ -0,0 +1,77 @@
+package ginSwagger
+
+import (
+	"net/http"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReDoc(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		assets := fstest.MapFS{
+			"redoc.standalone.js": &fstest.MapFile{Data: []byte("/* redoc */")},
+		}
+
+		router.GET("/redoc/*any", wrapHandler(nil, UseRenderer(ReDoc(assets)), URL("spec.json"), InstanceName("merge_users")))
+		router.GET("/redoc-yaml/*any", customWrapHandler(&Config{Renderer: ReDoc(assets), UseYAML: true, Title: "Reference"}, nil))
+
+		w := performRequest(http.MethodGet, "/redoc/index.html", router)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), `<redoc spec-url="spec.json"></redoc>`)
+		assert.Contains(t, w.Body.String(), `<title>Swagger UI</title>`)
+
+		w = performRequest(http.MethodGet, "/redoc/redoc.standalone.js", router)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/javascript", w.Header().Get("Content-Type"))
+		assert.Equal(t, "/* redoc */", w.Body.String())
+
+		w = performRequest(http.MethodGet, "/redoc-yaml/index.html", router)
+		assert.Contains(t, w.Body.String(), `<redoc spec-url="doc.yaml"></redoc>`)
+		assert.Contains(t, w.Body.String(), `<title>Reference</title>`)
+
+		assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/redoc/doc.json", router).Code)
+		assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/redoc/swagger-ui-bundle.js", router).Code)
+		assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/redoc/swagger-initializer.js", router).Code)
+	})
+}
+
+func TestUseRenderer(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.Renderer)
+
+	renderer := ReDoc(fstest.MapFS{})
+	configFunc := UseRenderer(renderer)
+	configFunc(&cfg)
+	assert.Equal(t, renderer, cfg.Renderer)
+}
+
+func TestNewMatcher(t *testing.T) {
+	assets := fstest.MapFS{
+		"swagger-ui.css.map": &fstest.MapFile{},
+		"dist/extra.js":      &fstest.MapFile{},
+	}
+
+	matcher := newMatcher(newSwaggerUIRenderer(assets))
+
+	assert.True(t, matcher.served("index.html"))
+	assert.True(t, matcher.served("swagger-initializer.js"))
+	assert.True(t, matcher.served("doc.json"))
+	assert.True(t, matcher.served("docs/v1.json"))
+	assert.True(t, matcher.served("swagger-ui.css.map"))
+	assert.True(t, matcher.served("dist/extra.js"))
+
+	assert.False(t, matcher.served("dist"))
+	assert.False(t, matcher.served("."))
+	assert.False(t, matcher.served(""))
+	assert.False(t, matcher.served("../swagger-ui.css.map"))
+	assert.False(t, matcher.served("nested/index.html"))
+	assert.False(t, matcher.served("index.html.bak"))
+	assert.False(t, matcher.served("redoc.standalone.js"))
+}
@@ -0,0 +1,51 @@
+package ginSwagger
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Register mounts the documentation at basePath of r, serving Swagger UI with the static
+// assets found at the root of assets like WrapFS. It registers basePath+"/*any" and basePath
+// for GET, HEAD and OPTIONS, and the handler redirects basePath and basePath+"/" to index.html.
+// r may be a *gin.RouterGroup, whose middleware then runs before the documentation.
+//
+// Routes conflicting with routes already registered on r are returned as an error instead
+// of panicking; routes registered before the conflict are kept.
+func Register(r gin.IRoutes, basePath string, assets fs.FS, options ...func(*Config)) error {
+	if !strings.HasPrefix(basePath, "/") {
+		return fmt.Errorf("ginSwagger: base path %q must begin with '/'", basePath)
+	}
+
+	basePath = strings.TrimSuffix(basePath, "/")
+	handler := WrapFS(assets, options...)
+
+	if basePath != "" {
+		if err := registerRoute(r, basePath, handler); err != nil {
+			return err
+		}
+	}
+
+	return registerRoute(r, basePath+"/*any", handler)
+}
+
+// routeMethods are the methods registered by Register.
+var routeMethods = []string{http.MethodGet, http.MethodHead, http.MethodOptions}
+
+// registerRoute registers handler for routeMethods at relativePath, turning the panic
+// of gin on conflicting routes into an error.
+func registerRoute(r gin.IRoutes, relativePath string, handler gin.HandlerFunc) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("ginSwagger: registering %s: %v", relativePath, recovered)
+		}
+	}()
+
+	r.Match(routeMethods, relativePath, handler)
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package ginSwagger
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	swaggerFilesV2 "github.com/swaggo/files/v2"
+)
+
+func TestRegister(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	require.NoError(t, Register(router, "/swagger", swaggerFilesV2.FS, InstanceName("validate")))
+
+	assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/swagger/index.html", router).Code)
+	assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/swagger/doc.json", router).Code)
+	assert.Equal(t, http.StatusNotFound, performRequest(http.MethodPost, "/swagger/index.html", router).Code)
+	assert.Equal(t, http.StatusOK, performRequest(http.MethodHead, "/swagger/index.html", router).Code)
+	assert.Equal(t, http.StatusNoContent, performRequest(http.MethodOptions, "/swagger/doc.json", router).Code)
+	assert.Equal(t, http.StatusNoContent, performRequest(http.MethodOptions, "/swagger/", router).Code)
+	assert.Equal(t, http.StatusNoContent, performRequest(http.MethodOptions, "/swagger", router).Code)
+	assert.Equal(t, http.StatusNotFound, performRequest(http.MethodOptions, "/swagger/nonexistent", router).Code)
+	assert.Equal(t, http.StatusNotFound, performRequest(http.MethodOptions, "/swagger/openapi.json", router).Code)
+	assert.Equal(t, http.StatusNotFound, performRequest(http.MethodOptions, "/swagger/preauthorize.json", router).Code)
+
+	w := performRequest(http.MethodGet, "/swagger", router)
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "swagger/index.html", w.Header().Get("Location"))
+
+	w = performRequest(http.MethodGet, "/swagger/", router)
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "index.html", w.Header().Get("Location"))
+
+	routes := map[string]bool{}
+	for _, route := range router.Routes() {
+		routes[route.Method+" "+route.Path] = true
+	}
+
+	assert.Equal(t, map[string]bool{
+		"GET /swagger": true, "GET /swagger/*any": true,
+		"HEAD /swagger": true, "HEAD /swagger/*any": true,
+		"OPTIONS /swagger": true, "OPTIONS /swagger/*any": true,
+	}, routes)
+}
+
+func TestRegisterRouterGroup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	admin := router.Group("/admin", func(ctx *gin.Context) {
+		if ctx.GetHeader("X-Admin") != "true" {
+			ctx.AbortWithStatus(http.StatusForbidden)
+		}
+	})
+
+	require.NoError(t, Register(admin, "/docs/", swaggerFilesV2.FS, InstanceName("validate")))
+
+	assert.Equal(t, http.StatusForbidden, performRequest(http.MethodGet, "/admin/docs/index.html", router).Code)
+	assert.Equal(t, http.StatusForbidden, performRequest(http.MethodGet, "/admin/docs", router).Code)
+
+	w := performConditionalRequest(router, "/admin/docs/doc.json", map[string]string{"X-Admin": "true"})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = performConditionalRequest(router, "/admin/docs", map[string]string{"X-Admin": "true"})
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "docs/index.html", w.Header().Get("Location"))
+}
+
+func TestRegisterRoot(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	require.NoError(t, Register(router, "/", swaggerFilesV2.FS, InstanceName("validate")))
+
+	w := performRequest(http.MethodGet, "/", router)
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "index.html", w.Header().Get("Location"))
+	assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/index.html", router).Code)
+}
+
+func TestRegisterErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	assert.EqualError(t, Register(router, "swagger", swaggerFilesV2.FS), `ginSwagger: base path "swagger" must begin with '/'`)
+
+	router.GET("/swagger/:id", func(ctx *gin.Context) {})
+
+	err := Register(router, "/swagger", swaggerFilesV2.FS)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ginSwagger: registering /swagger/*any: ")
+
+	router = gin.New()
+	router.GET("/docs", func(ctx *gin.Context) {})
+
+	err = Register(router, "/docs", swaggerFilesV2.FS)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ginSwagger: registering /docs: ")
+}
+
+func TestRegisterRedirectBehindProxy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	require.NoError(t, Register(router, "/swagger", swaggerFilesV2.FS, RewriteHost("127.0.0.1"), RedirectStatus(http.StatusMovedPermanently)))
+
+	for _, c := range []struct {
+		target, remoteAddr, location string
+	}{
+		{"/swagger", "127.0.0.1:1234", "/api/swagger/index.html"},
+		{"/swagger/?deepLinking=true", "127.0.0.1:1234", "/api/swagger/index.html?deepLinking=true"},
+		{"/swagger?deepLinking=true", "192.168.0.1:1234", "swagger/index.html?deepLinking=true"},
+		{"/swagger/", "192.168.0.1:1234", "index.html"},
+	} {
+		w := performRequestFrom(router, c.target, c.remoteAddr, map[string]string{"X-Forwarded-Prefix": "/api/"})
+		assert.Equal(t, http.StatusMovedPermanently, w.Code, c.target)
+		assert.Equal(t, c.location, w.Header().Get("Location"), c.target)
+		assert.Equal(t, "X-Forwarded-Prefix", w.Header().Get("Vary"), c.target)
+	}
+}
@@ -0,0 +1,203 @@
+package ginSwagger
+
+import (
+	"bytes"
+	"errors"
+	htmlTemplate "html/template"
+	"io/fs"
+	"regexp"
+	"strings"
+	textTemplate "text/template"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Renderer renders a documentation frontend for the served document.
+// The handler takes care of method checks, prefix detection, caching and the document
+// endpoints; the renderer only produces the pages of the frontend and its static assets.
+type Renderer interface {
+	// Pages returns the names, relative to the mount point, of the files generated
+	// from the configuration, e.g. index.html.
+	Pages() []string
+	// Render generates the named page.
+	Render(name string, config *Config) ([]byte, error)
+	// Assets returns the static files of the frontend, served as-is, or nil.
+	Assets() fs.FS
+}
+
+// PolicyRenderer is implemented by renderers whose frontend needs sources beyond the generated
+// Content-Security-Policy, e.g. to start workers from blobs.
+type PolicyRenderer interface {
+	Renderer
+	// PolicySources returns the sources to add to directives of the policy.
+	PolicySources() map[string][]string
+}
+
+// docFiles are the document endpoints served by the handler itself, as regular expressions.
+var docFiles = []string{`doc\.json`, `doc\.yaml`, `doc\.yml`, `openapi\.json`, `docs/[^/]+\.json`}
+
+// fileMatcher resolves the mount prefix and the requested file of a request.
+type fileMatcher struct {
+	// generated matches the document endpoints and the pages of the renderer.
+	generated *regexp.Regexp
+	// docs matches the document endpoints.
+	docs   *regexp.Regexp
+	assets fs.FS
+}
+
+// newMatcher builds the matcher for the document endpoints and the files served by renderer.
+func newMatcher(renderer Renderer) fileMatcher {
+	patterns := append([]string{regexp.QuoteMeta(preauthorizeFile)}, docFiles...)
+	for _, page := range renderer.Pages() {
+		patterns = append(patterns, regexp.QuoteMeta(page))
+	}
+
+	return fileMatcher{
+		generated: regexp.MustCompile(`^(?:` + strings.Join(patterns, "|") + `)$`),
+		docs:      regexp.MustCompile(`^(?:` + strings.Join(docFiles, "|") + `)$`),
+		assets:    renderer.Assets(),
+	}
+}
+
+// served reports whether name is a generated file or one of the static assets.
+func (m fileMatcher) served(name string) bool {
+	if m.generated.MatchString(name) {
+		return true
+	}
+
+	return m.isAsset(name)
+}
+
+// isAsset reports whether name is a regular file of the static assets.
+func (m fileMatcher) isAsset(name string) bool {
+	if m.assets == nil || !fs.ValidPath(name) || name == "." {
+		return false
+	}
+
+	info, err := fs.Stat(m.assets, name)
+
+	return err == nil && !info.IsDir()
+}
+
+// match returns the mount prefix and the requested file name relative to it.
+// The file name is taken from the wildcard parameter of the matched gin route, so the
+// prefix is resolved per request and one handler can be mounted at any number of paths.
+// Routes without a wildcard fall back to the shortest served suffix of the request path.
+func (m fileMatcher) match(ctx *gin.Context) (prefix, name string, ok bool) {
+	urlPath := ctx.Request.URL.Path
+
+	if param := wildcardParam(ctx.FullPath()); param != "" {
+		name = strings.TrimPrefix(ctx.Param(param), "/")
+
+		return strings.TrimSuffix(urlPath, name), name, m.served(name)
+	}
+
+	for i := len(urlPath) - 1; i >= 0; i-- {
+		if urlPath[i] != '/' {
+			continue
+		}
+
+		if name = urlPath[i+1:]; m.served(name) {
+			return urlPath[:i+1], name, true
+		}
+	}
+
+	return "", "", false
+}
+
+// isMountRoot reports whether the request is for the root of the mount: an empty wildcard,
+// e.g. "/swagger/" for "/swagger/*any", a gin route without parameters such as "/swagger",
+// or a path ending with a slash when the handler is not mounted on a gin route.
+func isMountRoot(ctx *gin.Context) bool {
+	fullPath := ctx.FullPath()
+
+	if param := wildcardParam(fullPath); param != "" {
+		return strings.Trim(ctx.Param(param), "/") == ""
+	}
+
+	if fullPath != "" {
+		return !strings.Contains(fullPath, "/:")
+	}
+
+	return strings.HasSuffix(ctx.Request.URL.Path, "/")
+}
+
+// wildcardParam returns the name of the catch-all parameter of a gin route, e.g. "any" for "/swagger/*any".
+func wildcardParam(fullPath string) string {
+	index := strings.LastIndex(fullPath, "/*")
+	if index == -1 {
+		return ""
+	}
+
+	return fullPath[index+2:]
+}
+
+// swaggerUIRenderer renders Swagger UI, serving its static assets from an fs.FS.
+type swaggerUIRenderer struct {
+	assets   fs.FS
+	index    *htmlTemplate.Template
+	js       *textTemplate.Template
+	css      *textTemplate.Template
+	redirect *htmlTemplate.Template
+	err      error
+}
+
+// SwaggerUI returns a Renderer serving Swagger UI with the static assets
+// (swagger-ui-bundle.js, swagger-ui.css, ...) found at the root of assets.
+func SwaggerUI(assets fs.FS) Renderer {
+	return newSwaggerUIRenderer(assets)
+}
+
+func newSwaggerUIRenderer(assets fs.FS) *swaggerUIRenderer {
+	// create a template with name
+	index, indexErr := htmlTemplate.New("swagger_index.html").Parse(swaggerIndexTpl)
+	js, jsErr := textTemplate.New("swagger_index.js").Parse(swaggerJSTpl)
+	css, cssErr := textTemplate.New("swagger_index.css").Parse(swaggerStyleTpl)
+	redirect, redirectErr := htmlTemplate.New("oauth2_redirect.html").Parse(oauth2RedirectTpl)
+
+	// Parse errors are reported by Render, and by New at startup.
+	return &swaggerUIRenderer{
+		assets:   assets,
+		index:    index,
+		js:       js,
+		css:      css,
+		redirect: redirect,
+		err:      errors.Join(indexErr, jsErr, cssErr, redirectErr),
+	}
+}
+
+func (r *swaggerUIRenderer) Pages() []string {
+	return []string{"index.html", "index.css", "swagger-initializer.js", oauth2RedirectPage, oauth2RedirectScript}
+}
+
+func (r *swaggerUIRenderer) Render(name string, config *Config) ([]byte, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	var (
+		buf bytes.Buffer
+		err error
+	)
+
+	switch name {
+	case "index.html":
+		err = r.index.Execute(&buf, config.toSwaggerConfig())
+	case "index.css":
+		err = r.css.Execute(&buf, config.toSwaggerConfig())
+	case "swagger-initializer.js":
+		err = r.js.Execute(&buf, config.toSwaggerConfig())
+	case oauth2RedirectPage:
+		err = r.redirect.Execute(&buf, config.toSwaggerConfig())
+	case oauth2RedirectScript:
+		buf.WriteString(oauth2RedirectJS)
+	default:
+		return nil, fs.ErrNotExist
+	}
+
+	return buf.Bytes(), err
+}
+
+func (r *swaggerUIRenderer) Assets() fs.FS {
+	return r.assets
+}
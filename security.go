@@ -0,0 +1,181 @@
+package ginSwagger
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cspNonce is rendered into the pages in place of the nonce and replaced on every request.
+const cspNonce = "gin-swagger-csp-nonce"
+
+// SecurityPolicy configures the Content-Security-Policy and related headers.
+// The policy is generated from the files the handler serves: scripts and stylesheets
+// carry a nonce that changes on every request, documents are fetched from 'self' or the
+// origin of an absolute URL, and everything else is denied.
+type SecurityPolicy struct {
+	// ReportOnly sends Content-Security-Policy-Report-Only, reporting violations without blocking.
+	ReportOnly bool
+	// ReportURI receives violation reports.
+	ReportURI string
+	// ExtraSources adds sources to directives of the generated policy, e.g.
+	// {"connect-src": {"https://api.example.com"}} for the API called by "Try it out"
+	// or the token URL of OAuth2 flows. Sources added to a directive replace its 'none'.
+	// Browsers ignore 'unsafe-inline' next to a nonce, so the nonce is left out of
+	// style-src when it is given 'unsafe-inline'.
+	ExtraSources map[string][]string
+	// ReferrerPolicy is the Referrer-Policy header. Defaults to "no-referrer".
+	ReferrerPolicy string
+	// FrameOptions is the X-Frame-Options header. Defaults to "DENY".
+	FrameOptions string
+}
+
+// contentSecurityPolicy returns the policy for the pages of config rendered by renderer,
+// with cspNonce in place of the nonce.
+func (config Config) contentSecurityPolicy(renderer Renderer) string {
+	nonce := "'nonce-" + cspNonce + "'"
+
+	directives := map[string][]string{
+		"default-src":     {"'none'"},
+		"base-uri":        {"'none'"},
+		"object-src":      {"'none'"},
+		"script-src":      {nonce, "'strict-dynamic'", "'self'"},
+		"style-src":       {"'self'", nonce},
+		"img-src":         {"'self'", "data:"},
+		"font-src":        {"'self'", "data:"},
+		"connect-src":     {"'self'"},
+		"form-action":     {"'self'"},
+		"frame-ancestors": {"'none'"},
+	}
+
+	if origin := urlOrigin(config.URL); origin != "" {
+		directives["connect-src"] = append(directives["connect-src"], origin)
+	}
+
+	if origin := urlOrigin(config.ValidatorURL); origin != "" {
+		directives["img-src"] = append(directives["img-src"], origin)
+	}
+
+	policy := config.SecurityHeaders
+
+	if policyRenderer, ok := renderer.(PolicyRenderer); ok {
+		addSources(directives, policyRenderer.PolicySources())
+	}
+
+	addSources(directives, policy.ExtraSources)
+
+	// Browsers ignore 'unsafe-inline' next to a nonce.
+	if containsString(directives["style-src"], "'unsafe-inline'") {
+		directives["style-src"] = removeString(directives["style-src"], nonce)
+	}
+
+	if policy.ReportURI != "" {
+		directives["report-uri"] = []string{policy.ReportURI}
+	}
+
+	names := make([]string, 0, len(directives))
+	for name := range directives {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	rendered := make([]string, 0, len(names))
+	for _, name := range names {
+		rendered = append(rendered, name+" "+strings.Join(directives[name], " "))
+	}
+
+	return strings.Join(rendered, "; ")
+}
+
+// addSources adds sources to directives, replacing their 'none'.
+func addSources(directives, extra map[string][]string) {
+	for directive, sources := range extra {
+		if len(directives[directive]) == 1 && directives[directive][0] == "'none'" {
+			directives[directive] = nil
+		}
+
+		directives[directive] = append(directives[directive], sources...)
+	}
+}
+
+// removeString returns list without value.
+func removeString(list []string, value string) []string {
+	kept := make([]string, 0, len(list))
+	for _, item := range list {
+		if item != value {
+			kept = append(kept, item)
+		}
+	}
+
+	return kept
+}
+
+// urlOrigin returns the origin of an absolute http(s) URL, or an empty string for relative URLs.
+func urlOrigin(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return ""
+	}
+
+	return parsed.Scheme + "://" + parsed.Host
+}
+
+// newNonce returns a random nonce for one response.
+func newNonce() string {
+	nonce := make([]byte, 16)
+	_, _ = rand.Read(nonce)
+
+	return base64.RawURLEncoding.EncodeToString(nonce)
+}
+
+// setSecurityHeaders sets the headers sent with every response.
+func (p *SecurityPolicy) setSecurityHeaders(ctx *gin.Context) {
+	referrerPolicy := p.ReferrerPolicy
+	if referrerPolicy == "" {
+		referrerPolicy = "no-referrer"
+	}
+
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.Header("Referrer-Policy", referrerPolicy)
+}
+
+// serveWithNonce writes an HTML page with a fresh nonce and the policy allowing it.
+// Every response is different, so it is never cached nor answered with 304.
+func (p *SecurityPolicy) serveWithNonce(ctx *gin.Context, name string, body []byte, policy string) {
+	nonce := newNonce()
+
+	header := "Content-Security-Policy"
+	if p.ReportOnly {
+		header = "Content-Security-Policy-Report-Only"
+	}
+
+	frameOptions := p.FrameOptions
+	if frameOptions == "" {
+		frameOptions = "DENY"
+	}
+
+	ctx.Header(header, strings.ReplaceAll(policy, cspNonce, nonce))
+	ctx.Header("X-Frame-Options", frameOptions)
+	ctx.Header("Cache-Control", "no-store")
+
+	body = bytes.ReplaceAll(body, []byte(cspNonce), []byte(nonce))
+
+	http.ServeContent(ctx.Writer, ctx.Request, name, time.Time{}, bytes.NewReader(body))
+}
+
+// nonce returns the placeholder rendered into the pages as nonce, or an empty string without SecurityHeaders.
+func (config Config) nonce() string {
+	if config.SecurityHeaders == nil {
+		return ""
+	}
+
+	return cspNonce
+}
@@ -0,0 +1,152 @@
+package ginSwagger
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	swaggerFilesV2 "github.com/swaggo/files/v2"
+)
+
+var noncePattern = regexp.MustCompile(`'nonce-([A-Za-z0-9_-]+)'`)
+
+func TestSecurityHeaders(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/*any", wrapFS(swaggerFilesV2.FS,
+			InstanceName("validate"),
+			URL("https://specs.example.com/v1/doc.json"),
+			SecurityHeaders(SecurityPolicy{
+				ExtraSources: map[string][]string{"connect-src": {"https://api.example.com"}, "frame-ancestors": {"https://portal.example.com"}},
+				ReportURI:    "/csp-reports",
+			})))
+
+		w := performRequest(http.MethodGet, "/index.html", router)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		policy := w.Header().Get("Content-Security-Policy")
+		match := noncePattern.FindStringSubmatch(policy)
+		require.NotNil(t, match, policy)
+
+		nonce := match[1]
+		assert.Equal(t, "base-uri 'none'; "+
+			"connect-src 'self' https://specs.example.com https://api.example.com; "+
+			"default-src 'none'; "+
+			"font-src 'self' data:; "+
+			"form-action 'self'; "+
+			"frame-ancestors https://portal.example.com; "+
+			"img-src 'self' data:; "+
+			"object-src 'none'; "+
+			"report-uri /csp-reports; "+
+			"script-src 'nonce-"+nonce+"' 'strict-dynamic' 'self'; "+
+			"style-src 'self' 'nonce-"+nonce+"'", policy)
+
+		assert.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, "no-referrer", w.Header().Get("Referrer-Policy"))
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+		body := w.Body.String()
+		assert.Equal(t, 5, strings.Count(body, `nonce="`+nonce+`"`))
+		assert.Contains(t, body, `<script src="./swagger-initializer.js" nonce="`+nonce+`">`)
+		assert.NotContains(t, body, cspNonce)
+		assert.NotContains(t, body, "style=")
+		assert.Empty(t, w.Header().Get("ETag"))
+
+		// Every response gets a new nonce.
+		next := performRequest(http.MethodGet, "/index.html", router)
+		assert.NotEqual(t, policy, next.Header().Get("Content-Security-Policy"))
+		assert.NotContains(t, next.Body.String(), nonce)
+
+		w = performRequest(http.MethodGet, "/oauth2-redirect.html", router)
+		redirectNonce := noncePattern.FindStringSubmatch(w.Header().Get("Content-Security-Policy"))[1]
+		assert.Contains(t, w.Body.String(), `<script src="oauth2-redirect.js" nonce="`+redirectNonce+`">`)
+
+		// Scripts, stylesheets and documents get the common headers only.
+		for _, target := range []string{"/swagger-initializer.js", "/index.css", "/swagger-ui-bundle.js", "/doc.json"} {
+			w = performRequest(http.MethodGet, target, router)
+			assert.Equal(t, http.StatusOK, w.Code, target)
+			assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"), target)
+			assert.Empty(t, w.Header().Get("Content-Security-Policy"), target)
+		}
+	})
+}
+
+func TestSecurityHeadersReDoc(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		assets := fstest.MapFS{"redoc.standalone.js": &fstest.MapFile{Data: []byte("/* redoc */")}}
+
+		router.GET("/*any", wrapHandler(nil, UseRenderer(ReDoc(assets)), InstanceName("validate"), SecurityHeaders(SecurityPolicy{})))
+
+		w := performRequest(http.MethodGet, "/index.html", router)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		policy := w.Header().Get("Content-Security-Policy")
+		match := noncePattern.FindStringSubmatch(policy)
+		require.NotNil(t, match, policy)
+
+		nonce := match[1]
+		assert.Contains(t, policy, "script-src 'nonce-"+nonce+"' 'strict-dynamic' 'self';")
+		assert.Contains(t, policy, "style-src 'self' 'unsafe-inline';")
+		assert.Contains(t, policy, "worker-src blob:")
+
+		body := w.Body.String()
+		assert.Contains(t, body, `<style nonce="`+nonce+`">`)
+		assert.Contains(t, body, `<script src="./redoc.standalone.js" nonce="`+nonce+`">`)
+	})
+}
+
+func TestSecurityHeadersReportOnly(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/*any", wrapFS(swaggerFilesV2.FS, SecurityHeaders(SecurityPolicy{
+			ReportOnly:     true,
+			ReferrerPolicy: "same-origin",
+			FrameOptions:   "SAMEORIGIN",
+		})))
+
+		w := performRequest(http.MethodGet, "/index.html", router)
+		assert.Empty(t, w.Header().Get("Content-Security-Policy"))
+		assert.Contains(t, w.Header().Get("Content-Security-Policy-Report-Only"), "default-src 'none'")
+		assert.Equal(t, "same-origin", w.Header().Get("Referrer-Policy"))
+		assert.Equal(t, "SAMEORIGIN", w.Header().Get("X-Frame-Options"))
+	})
+}
+
+func TestWithoutSecurityHeaders(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/*any", wrapFS(swaggerFilesV2.FS))
+
+		w := performRequest(http.MethodGet, "/index.html", router)
+		assert.Empty(t, w.Header().Get("Content-Security-Policy"))
+		assert.Empty(t, w.Header().Get("X-Content-Type-Options"))
+		assert.NotContains(t, w.Body.String(), "nonce")
+		assert.NotEmpty(t, w.Header().Get("ETag"))
+	})
+}
+
+func TestSecurityHeadersOption(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.SecurityHeaders)
+	assert.Empty(t, cfg.toSwaggerConfig().Nonce)
+
+	configFunc := SecurityHeaders(SecurityPolicy{ReportOnly: true})
+	configFunc(&cfg)
+	assert.Equal(t, &SecurityPolicy{ReportOnly: true}, cfg.SecurityHeaders)
+	assert.Equal(t, cspNonce, cfg.toSwaggerConfig().Nonce)
+}
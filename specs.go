@@ -0,0 +1,63 @@
+package ginSwagger
+
+import (
+	"net/url"
+	"strings"
+)
+
+// specsDir is the path, relative to the mount point, the documents listed in Config.Specs are served from.
+const specsDir = "docs/"
+
+// Spec is a swag instance listed in the spec selector of the UI.
+type Spec struct {
+	// Name shown in the selector. Defaults to InstanceName.
+	Name string
+	// InstanceName the swag document was registered with.
+	InstanceName string
+}
+
+func (spec Spec) displayName() string {
+	if spec.Name == "" {
+		return spec.InstanceName
+	}
+
+	return spec.Name
+}
+
+// url returns the path, relative to the mount point, the spec is served from.
+func (spec Spec) url() string {
+	return specsDir + url.PathEscape(spec.InstanceName) + ".json"
+}
+
+// specURL is an entry of the Swagger UI urls option.
+type specURL struct {
+	URL  string `json:"url"`
+	Name string `json:"name"`
+}
+
+// specURLs returns the Swagger UI urls option for the given specs.
+func specURLs(specs []Spec) []specURL {
+	urls := make([]specURL, 0, len(specs))
+	for _, spec := range specs {
+		urls = append(urls, specURL{URL: spec.url(), Name: spec.displayName()})
+	}
+
+	return urls
+}
+
+// findSpec returns the instance name of the spec served at the given unescaped path.
+func findSpec(specs []Spec, path string) (string, bool) {
+	if !strings.HasPrefix(path, specsDir) || !strings.HasSuffix(path, ".json") {
+		return "", false
+	}
+
+	instanceName := strings.TrimSuffix(strings.TrimPrefix(path, specsDir), ".json")
+
+	for _, spec := range specs {
+		if spec.InstanceName == instanceName {
+			return spec.InstanceName, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,68 @@
+package ginSwagger
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	swaggerFiles "github.com/swaggo/files"
+	"github.com/swaggo/swag"
+)
+
+type versionSwag struct {
+	version string
+}
+
+func (s *versionSwag) ReadDoc() string {
+	return `{"swagger": "2.0", "info": {"version": "` + s.version + `"}}`
+}
+
+func init() {
+	swag.Register("specs_v1", &versionSwag{version: "1.0"})
+	swag.Register("specs v2", &versionSwag{version: "2.0"})
+}
+
+func TestSpecsHandler(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/swagger/*any", wrapHandler(swaggerFiles.Handler,
+			Specs(Spec{Name: "v1", InstanceName: "specs_v1"}, Spec{InstanceName: "specs v2"}),
+			PrimarySpec("specs v2")))
+
+		w := performRequest(http.MethodGet, "/swagger/docs/specs_v1.json", router)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, `{"swagger": "2.0", "info": {"version": "1.0"}}`, w.Body.String())
+
+		w = performRequest(http.MethodGet, "/swagger/docs/specs%20v2.json", router)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `{"swagger": "2.0", "info": {"version": "2.0"}}`, w.Body.String())
+
+		assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/swagger/docs/unknown.json", router).Code)
+
+		w = performRequest(http.MethodGet, "/swagger/swagger-initializer.js", router)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"urls":[{"url":"docs/specs_v1.json","name":"v1"},{"url":"docs/specs%20v2.json","name":"specs v2"}],`)
+		assert.Contains(t, w.Body.String(), `"urls.primaryName":"specs v2",`)
+		assert.NotContains(t, w.Body.String(), `"url":"doc.json"`)
+	})
+}
+
+func TestSpecs(t *testing.T) {
+	var cfg Config
+	assert.Empty(t, cfg.Specs)
+	assert.NotContains(t, cfg.toSwaggerConfig().Options, `"urls"`)
+
+	configFunc := Specs(Spec{Name: "Public", InstanceName: "public"}, Spec{InstanceName: "admin"})
+	configFunc(&cfg)
+	assert.Len(t, cfg.Specs, 2)
+	assert.Contains(t, cfg.toSwaggerConfig().Options, `"urls.primaryName":"Public"`)
+
+	configFunc = PrimarySpec("admin")
+	configFunc(&cfg)
+	assert.Equal(t, "admin", cfg.PrimarySpec)
+	assert.Contains(t, cfg.toSwaggerConfig().Options, `"urls.primaryName":"admin"`)
+}
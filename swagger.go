@@ -2,12 +2,8 @@ package ginSwagger
 
 import (
 	htmlTemplate "html/template"
+	"io/fs"
 	"net/http"
-	"os"
-	"path/filepath"
-	"regexp"
-	"sync"
-	textTemplate "text/template"
 
 	"golang.org/x/net/webdav"
 
@@ -16,15 +12,14 @@ import (
 )
 
 type swaggerConfig struct {
-	URL                      string
-	DocExpansion             string
-	Title                    string
-	Oauth2RedirectURL        htmlTemplate.JS
-	DefaultModelsExpandDepth int
-	DeepLinking              bool
-	PersistAuthorization     bool
-	Oauth2DefaultClientID    string
-	Oauth2UsePkce            bool
+	// URL of the document, read by ReDoc; Swagger UI gets it from Options.
+	URL               string
+	Title             string
+	Oauth2RedirectURL htmlTemplate.JS
+	Options           string
+	OAuth2            string
+	Nonce             string
+	Preauthorize      bool
 }
 
 // Config stores ginSwagger configuration variables.
@@ -39,21 +34,134 @@ type Config struct {
 	PersistAuthorization     bool
 	Oauth2DefaultClientID    string
 	Oauth2UsePkce            bool
+	// Load doc.yaml instead of doc.json in the UI when URL is left at its default.
+	UseYAML bool
+	// Serve an OpenAPI 3 conversion of the swagger 2.0 document at openapi.json.
+	OpenAPI3 bool
+	// Transformers applied in order to the document before it is served.
+	DocTransformers []DocTransformer
+	// Rewrite host, schemes and basePath of the document from the incoming request.
+	RewriteHost bool
+	// Proxies (IPs or CIDRs) whose forwarding headers are honoured by RewriteHost, the redirect
+	// to index.html and CIDRGuard.
+	TrustedProxies []string
+	// Documents listed in the spec selector of the UI, served from docs/<InstanceName>.json.
+	// When set, URL is ignored by the UI.
+	Specs []Spec
+	// Name of the spec selected by default. Defaults to the first spec.
+	PrimarySpec string
+	// Instances merged into the document served from doc.json instead of InstanceName.
+	MergeInstances []string
+	// Renames conflicting paths and definitions of merged instances.
+	// When nil, conflicts are reported as errors.
+	MergeRenamer Renamer
+	// Frontend serving the documentation. Defaults to Swagger UI.
+	Renderer Renderer
+	// Cache-Control headers sent per kind of file.
+	CacheControl CachePolicy
+	// Serve static assets precompressed with brotli or gzip.
+	Precompress bool
+	// Callback URL of OAuth2 flows, absolute or relative to the UI.
+	// Defaults to oauth2-redirect.html next to index.html.
+	Oauth2RedirectURL string
+	// Reports per request whether the documentation is served, e.g. Toggle.Enabled.
+	// Defaults to always.
+	Enabled func() bool
+	// Status sent while the documentation is disabled. Defaults to 404.
+	DisabledStatus int
+	// Guards every request must pass, checked before anything is served.
+	Guards []Guard
+	// Status sent when a guard denies a request: 401, 403 or 404. Defaults to 401.
+	GuardDenyStatus int
+	// WWW-Authenticate challenge sent with 401. Defaults to Basic with Title as realm.
+	GuardChallenge string
+	// Content-Security-Policy and related headers sent with every response.
+	SecurityHeaders *SecurityPolicy
+	// Credentials the UI is pre-authorized with, evaluated per request.
+	Preauthorize Preauthorizer
+	// Settings of the OAuth2 Authorization dialog. Oauth2DefaultClientID and Oauth2UsePkce
+	// fill in the fields left unset.
+	OAuth2 *OAuth2Config
+	// Status of the redirect from the mount root, e.g. /swagger/, to index.html:
+	// 301, 302, 303, 307 or 308. Defaults to 302.
+	RedirectStatus int
+	// Cross-origin access to the documents, e.g. from a developer portal.
+	CORS *CORSPolicy
+
+	// Swagger UI options, see https://swagger.io/docs/open-source-tools/swagger-ui/usage/configuration/.
+	// Show the tag filter box.
+	Filter bool
+	// Expression the tag filter box is prefilled with; enables Filter.
+	FilterExpression string
+	// Open "Try it out" on every operation.
+	TryItOutEnabled bool
+	// HTTP methods (lower case) with "Try it out" enabled. Defaults to all.
+	SupportedSubmitMethods []string
+	// Show the duration of "Try it out" requests.
+	DisplayRequestDuration bool
+	// Show the operationId of operations.
+	DisplayOperationID bool
+	// Show vendor extensions (x-) of operations, parameters, responses and schemas.
+	ShowExtensions bool
+	// Show the pattern, maxLength, minLength, maximum and minimum of parameters.
+	ShowCommonExtensions bool
+	// Show the "example" or the "model" tab of schemas first.
+	DefaultModelRendering string
+	// Default expansion depth of the model in the model-example section. Nil keeps the default of Swagger UI, 1.
+	DefaultModelExpandDepth *int
+	// Maximum number of tags shown, 0 for all.
+	MaxDisplayedTags int
+	// Sort operations by path ("alpha") or method ("method"). Defaults to the server order.
+	OperationsSorter string
+	// Sort tags by name ("alpha"). Defaults to the server order.
+	TagsSorter string
+	// Theme of highlighted code: agate, arta, monokai, nord, obsidian, tomorrow-night or idea.
+	SyntaxHighlightTheme string
+	// URL of the spec validator badge. Empty disables the badge.
+	ValidatorURL string
+	// Send cookies and credentials with cross-origin "Try it out" requests.
+	WithCredentials bool
+	// Show generated curl, PowerShell and cmd snippets of requests.
+	RequestSnippetsEnabled bool
+	// Show the request after requestInterceptor ran, instead of the request as entered.
+	// Nil keeps the default of Swagger UI, true.
+	ShowMutatedRequest *bool
+}
+
+// docSource returns the source of the document served from doc.json.
+func (config Config) docSource() docSource {
+	if len(config.MergeInstances) == 0 {
+		return instanceSource(config.InstanceName)
+	}
+
+	return func() (string, error) {
+		return MergeDocs(config.MergeRenamer, config.MergeInstances...)
+	}
+}
+
+// docTransformers returns the transformers applied to the document, built-in ones first.
+func (config Config) docTransformers() []DocTransformer {
+	if !config.RewriteHost {
+		return config.DocTransformers
+	}
+
+	return append([]DocTransformer{hostRewriter(config.TrustedProxies)}, config.DocTransformers...)
 }
 
 func (config Config) toSwaggerConfig() swaggerConfig {
+	url := config.URL
+	if config.UseYAML && (url == "" || url == "doc.json") {
+		url = "doc.yaml"
+	}
+
 	return swaggerConfig{
-		URL:                      config.URL,
-		DeepLinking:              config.DeepLinking,
-		DocExpansion:             config.DocExpansion,
-		DefaultModelsExpandDepth: config.DefaultModelsExpandDepth,
-		Oauth2RedirectURL: "`${window.location.protocol}//${window.location.host}$" +
-			"{window.location.pathname.split('/').slice(0, window.location.pathname.split('/').length - 1).join('/')}" +
-			"/oauth2-redirect.html`",
-		Title:                 config.Title,
-		PersistAuthorization:  config.PersistAuthorization,
-		Oauth2DefaultClientID: config.Oauth2DefaultClientID,
-		Oauth2UsePkce:         config.Oauth2UsePkce,
+		URL:               url,
+		Options:           config.swaggerUIOptions(url),
+		OAuth2:            config.initOAuthOptions(),
+		Nonce:             config.nonce(),
+		Preauthorize:      config.Preauthorize != nil,
+		Oauth2RedirectURL: htmlTemplate.JS(config.oauth2RedirectURL()),
+		Title:             config.Title,
 	}
 }
 
@@ -118,8 +226,359 @@ func Oauth2UsePkce(usePkce bool) func(*Config) {
 	}
 }
 
+// Oauth2RedirectURL sets the callback URL of OAuth2 flows, absolute or relative to the UI,
+// e.g. when the redirect page is served at a separate route with OAuth2RedirectHandler.
+// Defaults to oauth2-redirect.html next to index.html.
+func Oauth2RedirectURL(url string) func(*Config) {
+	return func(c *Config) {
+		c.Oauth2RedirectURL = url
+	}
+}
+
+// OAuth2 sets the client ID, scopes, realm and the other settings of the OAuth2 Authorization dialog.
+func OAuth2(oauth2 OAuth2Config) func(*Config) {
+	return func(c *Config) {
+		c.OAuth2 = &oauth2
+	}
+}
+
+// Enabled sets the predicate evaluated on every request to decide whether the documentation
+// is served, e.g. the Enabled method of a Toggle or EnvDisabled("DISABLE_SWAGGER").
+func Enabled(predicate func() bool) func(*Config) {
+	return func(c *Config) {
+		c.Enabled = predicate
+	}
+}
+
+// DisabledStatus sets the status sent while the documentation is disabled.
+// Defaults to http.StatusNotFound, as if the route was not registered.
+func DisabledStatus(status int) func(*Config) {
+	return func(c *Config) {
+		c.DisabledStatus = status
+	}
+}
+
+// Guards appends guards every request for the UI, its assets and the documents must pass,
+// e.g. BasicAuthGuard, BearerGuard, APIKeyGuard, CIDRGuard or any `func(*gin.Context) bool`.
+func Guards(guards ...Guard) func(*Config) {
+	return func(c *Config) {
+		c.Guards = append(c.Guards, guards...)
+	}
+}
+
+// GuardDenyStatus sets the response to requests denied by a guard: http.StatusUnauthorized
+// with a WWW-Authenticate challenge, http.StatusForbidden, or http.StatusNotFound to hide
+// that the documentation exists. Defaults to http.StatusUnauthorized.
+func GuardDenyStatus(status int) func(*Config) {
+	return func(c *Config) {
+		c.GuardDenyStatus = status
+	}
+}
+
+// GuardChallenge sets the WWW-Authenticate header sent with 401 responses, e.g. "Bearer".
+// Defaults to a Basic challenge with Title as realm, making browsers prompt for credentials.
+func GuardChallenge(challenge string) func(*Config) {
+	return func(c *Config) {
+		c.GuardChallenge = challenge
+	}
+}
+
+// SecurityHeaders sends a Content-Security-Policy generated from the served files with the HTML
+// pages, using a new nonce for their scripts and stylesheets on every request, along with
+// X-Content-Type-Options, Referrer-Policy and X-Frame-Options.
+func SecurityHeaders(policy SecurityPolicy) func(*Config) {
+	return func(c *Config) {
+		c.SecurityHeaders = &policy
+	}
+}
+
+// Preauthorize sets the hook returning, per request of preauthorize.json, the credentials the UI is pre-authorized with,
+// e.g. the session token of the logged-in user. Only Swagger UI supports pre-authorization.
+func Preauthorize(preauthorizer Preauthorizer) func(*Config) {
+	return func(c *Config) {
+		c.Preauthorize = preauthorizer
+	}
+}
+
+// RedirectStatus sets the status of the redirect from the mount root to index.html.
+// Defaults to http.StatusFound; use http.StatusMovedPermanently or http.StatusPermanentRedirect
+// once the mount path is final, as browsers cache permanent redirects.
+func RedirectStatus(status int) func(*Config) {
+	return func(c *Config) {
+		c.RedirectStatus = status
+	}
+}
+
+// TrustedProxies adds proxies (IPs or CIDRs) whose forwarding headers are honoured,
+// e.g. by CIDRGuard, without rewriting the host of the documents like RewriteHost.
+func TrustedProxies(proxies ...string) func(*Config) {
+	return func(c *Config) {
+		c.TrustedProxies = appendProxies(c.TrustedProxies, proxies)
+	}
+}
+
+// appendProxies returns a new list of the proxies of both lists, so options never
+// write to a slice shared with the caller.
+func appendProxies(proxies, more []string) []string {
+	return append(append([]string(nil), proxies...), more...)
+}
+
+// CORS lets pages of other origins fetch the documents, e.g.
+// CORS(CORSPolicy{AllowOrigins: []string{"https://editor.swagger.io"}}).
+func CORS(policy CORSPolicy) func(*Config) {
+	return func(c *Config) {
+		c.CORS = &policy
+	}
+}
+
+// UseYAML makes the UI load the spec from doc.yaml instead of doc.json.
+// Defaults to false.
+func UseYAML(useYAML bool) func(*Config) {
+	return func(c *Config) {
+		c.UseYAML = useYAML
+	}
+}
+
+// OpenAPI3 serves an OpenAPI 3 conversion of the swag document at openapi.json.
+// The conversion runs on first request and is cached afterwards. Defaults to false.
+func OpenAPI3(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.OpenAPI3 = enabled
+	}
+}
+
+// DocTransformers appends transformers that modify the document before it is served
+// from doc.json, doc.yaml and openapi.json.
+func DocTransformers(transformers ...DocTransformer) func(*Config) {
+	return func(c *Config) {
+		c.DocTransformers = append(c.DocTransformers, transformers...)
+	}
+}
+
+// RewriteHost rewrites host, schemes and basePath of the served document per request,
+// so "Try it out" targets the host the client actually used. The X-Forwarded-Host,
+// X-Forwarded-Proto, X-Forwarded-Prefix and Forwarded headers are only honoured
+// when the request comes from one of the trusted proxies (IPs or CIDRs), which must
+// append to them: their last element is used. Responses vary on these headers.
+// The proxies are added to those of TrustedProxies, whatever the order of the options.
+func RewriteHost(trustedProxies ...string) func(*Config) {
+	return func(c *Config) {
+		c.RewriteHost = true
+		c.TrustedProxies = appendProxies(c.TrustedProxies, trustedProxies)
+	}
+}
+
+// Specs lists several swag instances in one UI with a spec selector in the top bar.
+// Each document is served from docs/<InstanceName>.json and the selected spec is kept
+// in the urls.primaryName query parameter, so deep links remember it.
+func Specs(specs ...Spec) func(*Config) {
+	return func(c *Config) {
+		c.Specs = specs
+	}
+}
+
+// PrimarySpec sets the name of the spec selected by default when Specs is used.
+func PrimarySpec(name string) func(*Config) {
+	return func(c *Config) {
+		c.PrimarySpec = name
+	}
+}
+
+// MergeInstances serves the merged documents of several swag instances from doc.json.
+// Conflicting paths and definitions are renamed with renamer, or reported as an error when it is nil.
+func MergeInstances(renamer Renamer, instanceNames ...string) func(*Config) {
+	return func(c *Config) {
+		c.MergeRenamer = renamer
+		c.MergeInstances = instanceNames
+	}
+}
+
+// UseRenderer sets the frontend serving the documentation, e.g. ReDoc.
+// Defaults to Swagger UI.
+func UseRenderer(renderer Renderer) func(*Config) {
+	return func(c *Config) {
+		c.Renderer = renderer
+	}
+}
+
+// CacheControl sets the Cache-Control headers sent per kind of file, e.g. RecommendedCachePolicy.
+// Generated pages and documents always carry a strong ETag and Last-Modified,
+// so conditional requests are answered with 304 Not Modified.
+func CacheControl(policy CachePolicy) func(*Config) {
+	return func(c *Config) {
+		c.CacheControl = policy
+	}
+}
+
+// Precompress serves static assets such as swagger-ui-bundle.js compressed with brotli or gzip,
+// negotiated from Accept-Encoding. Each asset is compressed once, on first request, and kept
+// in memory. Responses already compressed by an outer middleware are not compressed again.
+// Defaults to false.
+func Precompress(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.Precompress = enabled
+	}
+}
+
+// Filter shows the tag filter box at the top of the UI.
+// Defaults to false.
+func Filter(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.Filter = enabled
+	}
+}
+
+// FilterExpression shows the tag filter box prefilled with expression.
+func FilterExpression(expression string) func(*Config) {
+	return func(c *Config) {
+		c.FilterExpression = expression
+	}
+}
+
+// TryItOutEnabled opens "Try it out" on every operation by default.
+// Defaults to false.
+func TryItOutEnabled(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.TryItOutEnabled = enabled
+	}
+}
+
+// SupportedSubmitMethods limits "Try it out" to the given HTTP methods, e.g. "get", "post".
+// Defaults to all methods.
+func SupportedSubmitMethods(methods ...string) func(*Config) {
+	return func(c *Config) {
+		c.SupportedSubmitMethods = methods
+	}
+}
+
+// DisplayRequestDuration shows the duration of "Try it out" requests.
+// Defaults to false.
+func DisplayRequestDuration(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.DisplayRequestDuration = enabled
+	}
+}
+
+// DisplayOperationID shows the operationId of operations.
+// Defaults to false.
+func DisplayOperationID(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.DisplayOperationID = enabled
+	}
+}
+
+// ShowExtensions shows vendor extensions (x-) of operations, parameters, responses and schemas.
+// Defaults to false.
+func ShowExtensions(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.ShowExtensions = enabled
+	}
+}
+
+// ShowCommonExtensions shows the pattern, maxLength, minLength, maximum and minimum of parameters.
+// Defaults to false.
+func ShowCommonExtensions(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.ShowCommonExtensions = enabled
+	}
+}
+
+// DefaultModelRendering sets whether the "example" or the "model" tab of schemas is shown first.
+// Defaults to "example".
+func DefaultModelRendering(rendering string) func(*Config) {
+	return func(c *Config) {
+		c.DefaultModelRendering = rendering
+	}
+}
+
+// DefaultModelExpandDepth set the default expansion depth of the model in the model-example section.
+// Defaults to 1.
+func DefaultModelExpandDepth(depth int) func(*Config) {
+	return func(c *Config) {
+		c.DefaultModelExpandDepth = &depth
+	}
+}
+
+// MaxDisplayedTags limits the number of tags shown. Defaults to 0, showing all tags.
+func MaxDisplayedTags(maxTags int) func(*Config) {
+	return func(c *Config) {
+		c.MaxDisplayedTags = maxTags
+	}
+}
+
+// OperationsSorter sorts the operations of each tag by path ("alpha") or method ("method").
+// Defaults to the order of the document.
+func OperationsSorter(sorter string) func(*Config) {
+	return func(c *Config) {
+		c.OperationsSorter = sorter
+	}
+}
+
+// TagsSorter sorts tags by name ("alpha"). Defaults to the order of the document.
+func TagsSorter(sorter string) func(*Config) {
+	return func(c *Config) {
+		c.TagsSorter = sorter
+	}
+}
+
+// SyntaxHighlightTheme sets the theme of highlighted code:
+// agate, arta, monokai, nord, obsidian, tomorrow-night or idea.
+func SyntaxHighlightTheme(theme string) func(*Config) {
+	return func(c *Config) {
+		c.SyntaxHighlightTheme = theme
+	}
+}
+
+// ValidatorURL sets the URL of the spec validator badge, e.g. "https://validator.swagger.io/validator".
+// Defaults to no badge.
+func ValidatorURL(url string) func(*Config) {
+	return func(c *Config) {
+		c.ValidatorURL = url
+	}
+}
+
+// WithCredentials sends cookies and credentials with cross-origin "Try it out" requests.
+// Defaults to false.
+func WithCredentials(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.WithCredentials = enabled
+	}
+}
+
+// RequestSnippetsEnabled shows generated curl, PowerShell and cmd snippets of requests.
+// Defaults to false.
+func RequestSnippetsEnabled(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.RequestSnippetsEnabled = enabled
+	}
+}
+
+// ShowMutatedRequest shows the request after requestInterceptor ran instead of the request as entered.
+// Defaults to true.
+func ShowMutatedRequest(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.ShowMutatedRequest = &enabled
+	}
+}
+
 // WrapHandler wraps `http.Handler` into `gin.HandlerFunc`.
 func WrapHandler(handler *webdav.Handler, options ...func(*Config)) gin.HandlerFunc {
+	return WrapFS(webdavAssets(handler), options...)
+}
+
+// WrapFS returns a `gin.HandlerFunc` serving Swagger UI with the static assets found
+// at the root of assets, e.g. an embed.FS, os.DirFS or swaggo/files/v2.
+func WrapFS(assets fs.FS, options ...func(*Config)) gin.HandlerFunc {
+	return CustomWrapFS(newConfig(options...), assets)
+}
+
+// HTTPHandler returns an `http.Handler` like WrapFS, for routers other than gin.
+func HTTPHandler(assets fs.FS, options ...func(*Config)) http.Handler {
+	return NewHandler(newConfig(options...), assets)
+}
+
+// newConfig returns the default configuration with options applied.
+func newConfig(options ...func(*Config)) *Config {
 	var config = Config{
 		URL:                      "doc.json",
 		DocExpansion:             "list",
@@ -130,115 +589,61 @@ func WrapHandler(handler *webdav.Handler, options ...func(*Config)) gin.HandlerF
 		PersistAuthorization:     false,
 		Oauth2DefaultClientID:    "",
 		Oauth2UsePkce:            false,
+		UseYAML:                  false,
+		OpenAPI3:                 false,
 	}
 
 	for _, c := range options {
 		c(&config)
 	}
 
-	return CustomWrapHandler(&config, handler)
+	return &config
 }
 
 // CustomWrapHandler wraps `http.Handler` into `gin.HandlerFunc`.
 func CustomWrapHandler(config *Config, handler *webdav.Handler) gin.HandlerFunc {
-	var once sync.Once
-
-	if config.InstanceName == "" {
-		config.InstanceName = swag.Name
-	}
-
-	if config.Title == "" {
-		config.Title = "Swagger UI"
-	}
-
-	// create a template with name
-	index, _ := htmlTemplate.New("swagger_index.html").Parse(swaggerIndexTpl)
-	js, _ := textTemplate.New("swagger_index.js").Parse(swaggerJSTpl)
-	css, _ := textTemplate.New("swagger_index.css").Parse(swaggerStyleTpl)
-
-	var matcher = regexp.MustCompile(`(.*)(index\.html|index\.css|swagger-initializer\.js|doc\.json|favicon-16x16\.png|favicon-32x32\.png|/oauth2-redirect\.html|swagger-ui\.css|swagger-ui\.css\.map|swagger-ui\.js|swagger-ui\.js\.map|swagger-ui-bundle\.js|swagger-ui-bundle\.js\.map|swagger-ui-standalone-preset\.js|swagger-ui-standalone-preset\.js\.map)[?|.]*`)
-
-	return func(ctx *gin.Context) {
-		if ctx.Request.Method != http.MethodGet {
-			ctx.AbortWithStatus(http.StatusMethodNotAllowed)
-
-			return
-		}
-
-		matches := matcher.FindStringSubmatch(ctx.Request.RequestURI)
-
-		if len(matches) != 3 {
-			ctx.String(http.StatusNotFound, http.StatusText(http.StatusNotFound))
-
-			return
-		}
-
-		path := matches[2]
-		once.Do(func() {
-			handler.Prefix = matches[1]
-		})
+	return CustomWrapFS(config, webdavAssets(handler))
+}
 
-		switch filepath.Ext(path) {
-		case ".html":
-			ctx.Header("Content-Type", "text/html; charset=utf-8")
-		case ".css":
-			ctx.Header("Content-Type", "text/css; charset=utf-8")
-		case ".js":
-			ctx.Header("Content-Type", "application/javascript")
-		case ".png":
-			ctx.Header("Content-Type", "image/png")
-		case ".json":
-			ctx.Header("Content-Type", "application/json; charset=utf-8")
-		}
+// CustomWrapFS returns a `gin.HandlerFunc` serving the configured frontend,
+// Swagger UI with the static assets found at the root of assets by default.
+func CustomWrapFS(config *Config, assets fs.FS) gin.HandlerFunc {
+	return NewHandler(config, assets).HandlerFunc()
+}
 
-		switch path {
-		case "index.html":
-			_ = index.Execute(ctx.Writer, config.toSwaggerConfig())
-		case "index.css":
-			_ = css.Execute(ctx.Writer, config.toSwaggerConfig())
-		case "swagger-initializer.js":
-			_ = js.Execute(ctx.Writer, config.toSwaggerConfig())
-		case "doc.json":
-			doc, err := swag.ReadDoc(config.InstanceName)
-			if err != nil {
-				ctx.AbortWithStatus(http.StatusInternalServerError)
+// New returns a `gin.HandlerFunc` like CustomWrapFS, after validating config and
+// rendering the pages of the frontend. Invalid fields, unregistered swag instances,
+// documents that are not valid JSON and template errors are returned instead of
+// surfacing as responses with status 500 at runtime.
+func New(config *Config, assets fs.FS) (gin.HandlerFunc, error) {
+	handler := NewHandler(config, assets)
 
-				return
-			}
+	if err := handler.Config().Validate(); err != nil {
+		return nil, err
+	}
 
-			ctx.String(http.StatusOK, doc)
-		default:
-			handler.ServeHTTP(ctx.Writer, ctx.Request)
-		}
+	if err := handler.state.Load().renderErr(); err != nil {
+		return nil, err
 	}
+
+	return handler.HandlerFunc(), nil
 }
 
 // DisablingWrapHandler turn handler off
 // if specified environment variable passed.
+// The variable is read on every request, so it can be changed without a restart.
 func DisablingWrapHandler(handler *webdav.Handler, envName string) gin.HandlerFunc {
-	if os.Getenv(envName) != "" {
-		return func(c *gin.Context) {
-			// Simulate behavior when route unspecified and
-			// return 404 HTTP code
-			c.String(http.StatusNotFound, "")
-		}
-	}
-
-	return WrapHandler(handler)
+	return WrapHandler(handler, Enabled(EnvDisabled(envName)))
 }
 
 // DisablingCustomWrapHandler turn handler off
 // if specified environment variable passed.
+// The variable is read on every request, so it can be changed without a restart.
 func DisablingCustomWrapHandler(config *Config, handler *webdav.Handler, envName string) gin.HandlerFunc {
-	if os.Getenv(envName) != "" {
-		return func(c *gin.Context) {
-			// Simulate behavior when route unspecified and
-			// return 404 HTTP code
-			c.String(http.StatusNotFound, "")
-		}
-	}
+	disablingConfig := *config
+	disablingConfig.Enabled = EnvDisabled(envName)
 
-	return CustomWrapHandler(config, handler)
+	return CustomWrapHandler(&disablingConfig, handler)
 }
 
 const swaggerStyleTpl = `
@@ -259,17 +664,21 @@ body {
   margin:0;
   background: #fafafa;
 }
+
+.svg-symbols
+{
+    position: absolute;
+    width: 0;
+    height: 0;
+}
 `
 
 const swaggerJSTpl = `
 window.onload = function() {
   // Build a system
-  const ui = SwaggerUIBundle({
-    url: "{{.URL}}",
+  const ui = SwaggerUIBundle(Object.assign({{.Options}}, {
     dom_id: '#swagger-ui',
-    validatorUrl: null,
     oauth2RedirectUrl: {{.Oauth2RedirectURL}},
-    persistAuthorization: {{.PersistAuthorization}},
     presets: [
       SwaggerUIBundle.presets.apis,
       SwaggerUIStandalonePreset
@@ -277,18 +686,28 @@ window.onload = function() {
     plugins: [
       SwaggerUIBundle.plugins.DownloadUrl
     ],
-	layout: "StandaloneLayout",
-    docExpansion: "{{.DocExpansion}}",
-	deepLinking: {{.DeepLinking}},
-	defaultModelsExpandDepth: {{.DefaultModelsExpandDepth}}
-  })
-
-  const defaultClientId = "{{.Oauth2DefaultClientID}}";
-  if (defaultClientId) {
-    ui.initOAuth({
-      clientId: defaultClientId,
-      usePkceWithAuthorizationCodeGrant: {{.Oauth2UsePkce}}
-    })
+    layout: "StandaloneLayout",
+    onComplete: function() {
+{{- if .Preauthorize}}
+      fetch("preauthorize.json", {credentials: "same-origin", headers: {"Accept": "application/json"}})
+        .then(function(response) { return response.ok ? response.json() : {} })
+        .then(function(credentials) {
+          Object.keys(credentials).sort().forEach(function(scheme) {
+            const credential = credentials[scheme]
+            if (credential.apiKey) {
+              window.ui.preauthorizeApiKey(scheme, credential.apiKey)
+            } else {
+              window.ui.preauthorizeBasic(scheme, credential.username, credential.password)
+            }
+          })
+        })
+{{- end}}
+    }
+  }))
+
+  const oauth2 = {{.OAuth2}};
+  if (oauth2) {
+    ui.initOAuth(oauth2)
   }
 
   window.ui = ui
@@ -301,15 +720,15 @@ const swaggerIndexTpl = `<!-- HTML for static distribution bundle build -->
 <head>
   <meta charset="UTF-8">
   <title>{{.Title}}</title>
-  <link rel="stylesheet" type="text/css" href="./swagger-ui.css" >
+  <link rel="stylesheet" type="text/css" href="./swagger-ui.css"{{if .Nonce}} nonce="{{.Nonce}}"{{end}} >
   <link rel="icon" type="image/png" href="./favicon-32x32.png" sizes="32x32" />
   <link rel="icon" type="image/png" href="./favicon-16x16.png" sizes="16x16" />
-  <link rel="stylesheet" type="text/css" href="index.css" />
+  <link rel="stylesheet" type="text/css" href="index.css"{{if .Nonce}} nonce="{{.Nonce}}"{{end}} />
 </head>
 
 <body>
 
-<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" style="position:absolute;width:0;height:0">
+<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" class="svg-symbols">
   <defs>
     <symbol viewBox="0 0 20 20" id="unlocked">
           <path d="M15.8 8H14V5.6C14 2.703 12.665 1 10 1 7.334 1 6 2.703 6 5.6V6h2v-.801C8 3.754 8.797 3 10 3c1.203 0 2 .754 2 2.199V8H4c-.553 0-1 .646-1 1.199V17c0 .549.428 1.139.951 1.307l1.197.387C5.672 18.861 6.55 19 7.1 19h5.8c.549 0 1.428-.139 1.951-.307l1.196-.387c.524-.167.953-.757.953-1.306V9.199C17 8.646 16.352 8 15.8 8z"></path>
@@ -345,9 +764,9 @@ const swaggerIndexTpl = `<!-- HTML for static distribution bundle build -->
 
 <div id="swagger-ui"></div>
 
-<script src="./swagger-ui-bundle.js"> </script>
-<script src="./swagger-ui-standalone-preset.js"> </script>
-<script src="./swagger-initializer.js"> </script>
+<script src="./swagger-ui-bundle.js"{{if .Nonce}} nonce="{{.Nonce}}"{{end}}> </script>
+<script src="./swagger-ui-standalone-preset.js"{{if .Nonce}} nonce="{{.Nonce}}"{{end}}> </script>
+<script src="./swagger-initializer.js"{{if .Nonce}} nonce="{{.Nonce}}"{{end}}> </script>
 </body>
 
 </html>
@@ -1,11 +1,14 @@
 package ginSwagger
 
 import (
+	"errors"
+	"io/fs"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
-	"os"
+	"strconv"
 	"testing"
+	"testing/fstest"
 
 	"github.com/gin-contrib/gzip"
 	"github.com/swaggo/swag"
@@ -13,6 +16,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	swaggerFiles "github.com/swaggo/files"
+	swaggerFilesV2 "github.com/swaggo/files/v2"
 )
 
 type mockedSwag struct{}
@@ -23,129 +27,323 @@ func (s *mockedSwag) ReadDoc() string {
 }
 
 func TestWrapHandler(t *testing.T) {
-	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
 
-	router.GET("/*any", WrapHandler(swaggerFiles.Handler, URL("https://github.com/swaggo/gin-swagger")))
+		router.GET("/*any", wrapHandler(swaggerFiles.Handler, URL("https://github.com/swaggo/gin-swagger")))
 
-	assert.Equal(t, http.StatusOK, performRequest("GET", "/index.html", router).Code)
+		assert.Equal(t, http.StatusOK, performRequest("GET", "/index.html", router).Code)
+	})
 }
 
 func TestWrapCustomHandler(t *testing.T) {
-	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.Any("/*any", customWrapHandler(&Config{}, swaggerFiles.Handler))
+
+		w1 := performRequest(http.MethodGet, "/index.html", router)
+		assert.Equal(t, http.StatusOK, w1.Code)
+		assert.Equal(t, w1.Header()["Content-Type"][0], "text/html; charset=utf-8")
+
+		// The default instance is registered late, by the first run.
+		if swag.GetSwagger(swag.Name) == nil {
+			assert.Equal(t, http.StatusInternalServerError, performRequest(http.MethodGet, "/doc.json", router).Code)
+
+			swag.Register(swag.Name, &mockedSwag{})
+		}
+
+		doc := &mockedSwag{}
+
+		w2 := performRequest(http.MethodGet, "/doc.json", router)
+		assert.Equal(t, http.StatusOK, w2.Code)
+		assert.Equal(t, w2.Header()["Content-Type"][0], "application/json; charset=utf-8")
+
+		// Perform body rendering validation
+		w2Body, err := ioutil.ReadAll(w2.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, doc.ReadDoc(), string(w2Body))
+
+		w3 := performRequest(http.MethodGet, "/favicon-16x16.png", router)
+		assert.Equal(t, http.StatusOK, w3.Code)
+		assert.Equal(t, w3.Header()["Content-Type"][0], "image/png")
+
+		w4 := performRequest(http.MethodGet, "/swagger-ui.css", router)
+		assert.Equal(t, http.StatusOK, w4.Code)
+		assert.Equal(t, w4.Header()["Content-Type"][0], "text/css; charset=utf-8")
+
+		w5 := performRequest(http.MethodGet, "/swagger-ui-bundle.js", router)
+		assert.Equal(t, http.StatusOK, w5.Code)
+		assert.Equal(t, w5.Header()["Content-Type"][0], "application/javascript")
+
+		w6 := performRequest(http.MethodGet, "/index.css", router)
+		assert.Equal(t, http.StatusOK, w6.Code)
+		assert.Equal(t, w6.Header()["Content-Type"][0], "text/css; charset=utf-8")
+
+		w7 := performRequest(http.MethodGet, "/swagger-initializer.js", router)
+		assert.Equal(t, http.StatusOK, w7.Code)
+		assert.Equal(t, w7.Header()["Content-Type"][0], "application/javascript")
+
+		assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/notfound", router).Code)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, performRequest(http.MethodPost, "/index.html", router).Code)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, performRequest(http.MethodPut, "/index.html", router).Code)
+
+		w8 := performRequest(http.MethodHead, "/index.html", router)
+		assert.Equal(t, http.StatusOK, w8.Code)
+		assert.Equal(t, "text/html; charset=utf-8", w8.Header().Get("Content-Type"))
+		assert.Equal(t, w1.Header().Get("Content-Length"), w8.Header().Get("Content-Length"))
+		assert.Empty(t, w8.Body.String())
+	})
+}
+
+func TestWrapFS(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		assets := fstest.MapFS{
+			"swagger-ui-bundle.js": &fstest.MapFile{Data: []byte("/* pinned build */")},
+		}
+
+		router.GET("/embedded/*any", wrapFS(swaggerFilesV2.FS))
+		router.GET("/pinned/*any", customWrapFS(&Config{}, assets))
 
-	router.Any("/*any", CustomWrapHandler(&Config{}, swaggerFiles.Handler))
+		w1 := performRequest(http.MethodGet, "/embedded/index.html", router)
+		assert.Equal(t, http.StatusOK, w1.Code)
+		assert.Equal(t, "text/html; charset=utf-8", w1.Header().Get("Content-Type"))
 
-	w1 := performRequest(http.MethodGet, "/index.html", router)
-	assert.Equal(t, http.StatusOK, w1.Code)
-	assert.Equal(t, w1.Header()["Content-Type"][0], "text/html; charset=utf-8")
+		w2 := performRequest(http.MethodGet, "/embedded/swagger-ui-bundle.js", router)
+		assert.Equal(t, http.StatusOK, w2.Code)
+		assert.Equal(t, "application/javascript", w2.Header().Get("Content-Type"))
 
-	assert.Equal(t, http.StatusInternalServerError, performRequest(http.MethodGet, "/doc.json", router).Code)
+		w3 := performRequest(http.MethodGet, "/embedded/favicon-32x32.png", router)
+		assert.Equal(t, http.StatusOK, w3.Code)
+		assert.Equal(t, "image/png", w3.Header().Get("Content-Type"))
 
-	doc := &mockedSwag{}
-	swag.Register(swag.Name, doc)
+		w4 := performRequest(http.MethodGet, "/pinned/swagger-ui-bundle.js", router)
+		assert.Equal(t, http.StatusOK, w4.Code)
+		assert.Equal(t, "/* pinned build */", w4.Body.String())
 
-	w2 := performRequest(http.MethodGet, "/doc.json", router)
-	assert.Equal(t, http.StatusOK, w2.Code)
-	assert.Equal(t, w2.Header()["Content-Type"][0], "application/json; charset=utf-8")
+		assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/pinned/swagger-ui.css", router).Code)
+	})
+}
+
+func TestMultiplePrefixes(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		handler := wrapHandler(swaggerFiles.Handler)
+
+		router.GET("/swagger/*any", handler)
+		router.GET("/internal/docs/*any", handler)
+		router.GET("/other/*path", wrapHandler(swaggerFiles.Handler, InstanceName("yaml")))
+		router.Group("/v1").GET("/docs/*any", wrapHandler(swaggerFiles.Handler))
+		router.GET("/exact/swagger-ui.css", handler)
+
+		for _, prefix := range []string{"/swagger/", "/internal/docs/", "/other/", "/v1/docs/", "/swagger/"} {
+			w1 := performRequest(http.MethodGet, prefix+"swagger-ui.css", router)
+			assert.Equal(t, http.StatusOK, w1.Code, prefix)
+			assert.Equal(t, "text/css; charset=utf-8", w1.Header().Get("Content-Type"), prefix)
+			assert.NotEmpty(t, w1.Body.String(), prefix)
+
+			w2 := performRequest(http.MethodGet, prefix+"favicon-16x16.png", router)
+			assert.Equal(t, http.StatusOK, w2.Code, prefix)
+
+			w3 := performRequest(http.MethodGet, prefix+"index.html?foo=bar", router)
+			assert.Equal(t, http.StatusOK, w3.Code, prefix)
+
+			// Without the wildcard of a gin route, ServeHTTP takes the shortest served suffix.
+			if !netHTTPAdapter {
+				assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, prefix+"nested/swagger-ui.css", router).Code, prefix)
+			}
+		}
+
+		assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/exact/swagger-ui.css", router).Code)
+	})
+}
+
+func TestWildcardParam(t *testing.T) {
+	assert.Equal(t, "any", wildcardParam("/swagger/*any"))
+	assert.Equal(t, "path", wildcardParam("/*path"))
+	assert.Equal(t, "", wildcardParam("/swagger/:id/index.html"))
+	assert.Equal(t, "", wildcardParam(""))
+}
+
+func TestWebdavAssets(t *testing.T) {
+	assert.Nil(t, webdavAssets(nil))
+
+	assets := webdavAssets(swaggerFiles.Handler)
 
-	// Perform body rendering validation
-	w2Body, err := ioutil.ReadAll(w2.Body)
+	data, err := fs.ReadFile(assets, "favicon-16x16.png")
 	assert.NoError(t, err)
-	assert.Equal(t, doc.ReadDoc(), string(w2Body))
+	assert.NotEmpty(t, data)
 
-	w3 := performRequest(http.MethodGet, "/favicon-16x16.png", router)
-	assert.Equal(t, http.StatusOK, w3.Code)
-	assert.Equal(t, w3.Header()["Content-Type"][0], "image/png")
+	_, err = fs.ReadFile(assets, "missing.js")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
 
-	w4 := performRequest(http.MethodGet, "/swagger-ui.css", router)
-	assert.Equal(t, http.StatusOK, w4.Code)
-	assert.Equal(t, w4.Header()["Content-Type"][0], "text/css; charset=utf-8")
+	_, err = assets.Open("../swagger-ui.css")
+	assert.ErrorIs(t, err, fs.ErrInvalid)
+}
 
-	w5 := performRequest(http.MethodGet, "/swagger-ui-bundle.js", router)
-	assert.Equal(t, http.StatusOK, w5.Code)
-	assert.Equal(t, w5.Header()["Content-Type"][0], "application/javascript")
+type yamlSwag struct{}
 
-	w6 := performRequest(http.MethodGet, "/index.css", router)
-	assert.Equal(t, http.StatusOK, w6.Code)
-	assert.Equal(t, w6.Header()["Content-Type"][0], "text/css; charset=utf-8")
+func (s *yamlSwag) ReadDoc() string {
+	return `{"swagger":"2.0","info":{"title":"yaml","version":"1.0"},"paths":{"/ping":{"get":{"responses":{"200":{"description":"ok"}}}}}}`
+}
 
-	w7 := performRequest(http.MethodGet, "/swagger-initializer.js", router)
-	assert.Equal(t, http.StatusOK, w7.Code)
-	assert.Equal(t, w7.Header()["Content-Type"][0], "application/javascript")
+func init() {
+	swag.Register("yaml", &yamlSwag{})
+}
 
-	assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/notfound", router).Code)
+func TestDocYAML(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/yaml/*any", wrapHandler(swaggerFiles.Handler, InstanceName("yaml")))
+		router.GET("/missing/*any", wrapHandler(swaggerFiles.Handler, InstanceName("missing")))
+
+		expected := `swagger: "2.0"
+info:
+  title: yaml
+  version: "1.0"
+paths:
+  /ping:
+    get:
+      responses:
+        "200":
+          description: ok
+`
+
+		for _, target := range []string{"/yaml/doc.yaml", "/yaml/doc.yml"} {
+			w := performRequest(http.MethodGet, target, router)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "application/yaml; charset=utf-8", w.Header().Get("Content-Type"))
+			assert.Equal(t, expected, w.Body.String())
+		}
+
+		assert.Equal(t, http.StatusInternalServerError, performRequest(http.MethodGet, "/missing/doc.yaml", router).Code)
+	})
+}
 
-	assert.Equal(t, http.StatusMethodNotAllowed, performRequest(http.MethodPost, "/index.html", router).Code)
+func TestJSONToYAML(t *testing.T) {
+	doc, err := jsonToYAML(`{"enum": ["yes", "no", "on", "off", "Y", "maybe"], "default": "true", "path": "\/users\/{id}", "pattern": "^\\d+\/$"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `enum:
+  - "yes"
+  - "no"
+  - "on"
+  - "off"
+  - "Y"
+  - maybe
+default: "true"
+path: /users/{id}
+pattern: ^\d+/$
+`, string(doc))
+}
 
-	assert.Equal(t, http.StatusMethodNotAllowed, performRequest(http.MethodPut, "/index.html", router).Code)
+func TestJSONToYAMLQuotesYAML11Scalars(t *testing.T) {
+	doc, err := jsonToYAML(`{"values": ["1:20", "1_000", "0b101", "0o17", "017", "0x1F", "1.5e3", ".inf", "-.Inf", ".NaN", "~", "Null", "", "2001-12-14", "2001-12-14 21:59:43.10 -5", "<<", "=", "1:2:3x", "v1.0", "0x"]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `values:
+  - "1:20"
+  - "1_000"
+  - "0b101"
+  - "0o17"
+  - "017"
+  - "0x1F"
+  - "1.5e3"
+  - ".inf"
+  - "-.Inf"
+  - ".NaN"
+  - "~"
+  - "Null"
+  - ""
+  - "2001-12-14"
+  - "2001-12-14 21:59:43.10 -5"
+  - "<<"
+  - "="
+  - 1:2:3x
+  - v1.0
+  - 0x
+`, string(doc))
 }
 
 func TestDisablingWrapHandler(t *testing.T) {
-	gin.SetMode(gin.TestMode)
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
 
-	router := gin.New()
-	disablingKey := "SWAGGER_DISABLE"
+		router := gin.New()
+		disablingKey := "SWAGGER_DISABLE"
 
-	router.GET("/simple/*any", DisablingWrapHandler(swaggerFiles.Handler, disablingKey))
+		router.GET("/simple/*any", disablingWrapHandler(swaggerFiles.Handler, disablingKey))
 
-	assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/simple/index.html", router).Code)
-	assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/simple/doc.json", router).Code)
+		assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/simple/index.html", router).Code)
+		assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/simple/doc.json", router).Code)
 
-	assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/simple/favicon-16x16.png", router).Code)
-	assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/simple/notfound", router).Code)
+		assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/simple/favicon-16x16.png", router).Code)
+		assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/simple/notfound", router).Code)
 
-	_ = os.Setenv(disablingKey, "true")
+		t.Setenv(disablingKey, "true")
 
-	router.GET("/disabling/*any", DisablingWrapHandler(swaggerFiles.Handler, disablingKey))
+		router.GET("/disabling/*any", disablingWrapHandler(swaggerFiles.Handler, disablingKey))
 
-	assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/disabling/index.html", router).Code)
-	assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/disabling/doc.json", router).Code)
-	assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/disabling/oauth2-redirect.html", router).Code)
-	assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/disabling/notfound", router).Code)
+		assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/disabling/index.html", router).Code)
+		assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/disabling/doc.json", router).Code)
+		assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/disabling/oauth2-redirect.html", router).Code)
+		assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/disabling/notfound", router).Code)
+	})
 }
 
 func TestDisablingCustomWrapHandler(t *testing.T) {
-	gin.SetMode(gin.TestMode)
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
 
-	router := gin.New()
-	disablingKey := "SWAGGER_DISABLE2"
+		router := gin.New()
+		disablingKey := "SWAGGER_DISABLE2"
 
-	router.GET("/simple/*any", DisablingCustomWrapHandler(&Config{}, swaggerFiles.Handler, disablingKey))
+		router.GET("/simple/*any", disablingCustomWrapHandler(&Config{}, swaggerFiles.Handler, disablingKey))
 
-	assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/simple/index.html", router).Code)
+		assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/simple/index.html", router).Code)
 
-	_ = os.Setenv(disablingKey, "true")
+		t.Setenv(disablingKey, "true")
 
-	router.GET("/disabling/*any", DisablingCustomWrapHandler(&Config{}, swaggerFiles.Handler, disablingKey))
+		router.GET("/disabling/*any", disablingCustomWrapHandler(&Config{}, swaggerFiles.Handler, disablingKey))
 
-	assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/disabling/index.html", router).Code)
+		assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/disabling/index.html", router).Code)
+	})
 }
 
 func TestWithGzipMiddleware(t *testing.T) {
-	gin.SetMode(gin.TestMode)
-	router := gin.New()
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
 
-	router.Use(gzip.Gzip(gzip.BestSpeed))
+		router.Use(gzip.Gzip(gzip.BestSpeed))
 
-	router.GET("/*any", WrapHandler(swaggerFiles.Handler))
+		router.GET("/*any", wrapHandler(swaggerFiles.Handler))
 
-	w1 := performRequest(http.MethodGet, "/index.html", router)
-	assert.Equal(t, http.StatusOK, w1.Code)
-	assert.Equal(t, w1.Header()["Content-Type"][0], "text/html; charset=utf-8")
+		w1 := performRequest(http.MethodGet, "/index.html", router)
+		assert.Equal(t, http.StatusOK, w1.Code)
+		assert.Equal(t, w1.Header()["Content-Type"][0], "text/html; charset=utf-8")
 
-	w2 := performRequest(http.MethodGet, "/swagger-ui.css", router)
-	assert.Equal(t, http.StatusOK, w2.Code)
-	assert.Equal(t, w2.Header()["Content-Type"][0], "text/css; charset=utf-8")
+		w2 := performRequest(http.MethodGet, "/swagger-ui.css", router)
+		assert.Equal(t, http.StatusOK, w2.Code)
+		assert.Equal(t, w2.Header()["Content-Type"][0], "text/css; charset=utf-8")
 
-	w3 := performRequest(http.MethodGet, "/swagger-ui-bundle.js", router)
-	assert.Equal(t, http.StatusOK, w3.Code)
-	assert.Equal(t, w3.Header()["Content-Type"][0], "application/javascript")
+		w3 := performRequest(http.MethodGet, "/swagger-ui-bundle.js", router)
+		assert.Equal(t, http.StatusOK, w3.Code)
+		assert.Equal(t, w3.Header()["Content-Type"][0], "application/javascript")
 
-	w4 := performRequest(http.MethodGet, "/doc.json", router)
-	assert.Equal(t, http.StatusOK, w4.Code)
-	assert.Equal(t, w4.Header()["Content-Type"][0], "application/json; charset=utf-8")
+		w4 := performRequest(http.MethodGet, "/doc.json", router)
+		assert.Equal(t, http.StatusOK, w4.Code)
+		assert.Equal(t, w4.Header()["Content-Type"][0], "application/json; charset=utf-8")
+	})
 }
 
 func performRequest(method, target string, router *gin.Engine) *httptest.ResponseRecorder {
@@ -267,3 +465,120 @@ func TestOauth2UsePkce(t *testing.T) {
 	configFunc(&cfg)
 	assert.Equal(t, false, cfg.Oauth2UsePkce)
 }
+
+func TestUseYAML(t *testing.T) {
+	var cfg Config
+	assert.Equal(t, false, cfg.UseYAML)
+
+	configFunc := UseYAML(true)
+	configFunc(&cfg)
+	assert.Equal(t, true, cfg.UseYAML)
+	assert.Equal(t, "doc.yaml", cfg.toSwaggerConfig().URL)
+
+	cfg.URL = "https://example.com/spec.json"
+	assert.Equal(t, "https://example.com/spec.json", cfg.toSwaggerConfig().URL)
+
+	configFunc = UseYAML(false)
+	configFunc(&cfg)
+	assert.Equal(t, false, cfg.UseYAML)
+}
+
+func TestHandlerReconfigure(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		config := &Config{URL: "first.json"}
+		handler := NewHandler(config, swaggerFilesV2.FS)
+
+		router.GET("/*any", adapt(handler))
+
+		w1 := performRequest(http.MethodGet, "/swagger-initializer.js", router)
+		assert.Equal(t, http.StatusOK, w1.Code)
+		assert.Contains(t, w1.Body.String(), `"url":"first.json"`)
+		assert.Equal(t, w1.Header().Get("Content-Length"), strconv.Itoa(w1.Body.Len()))
+
+		// The handler works on a copy, later changes need Reconfigure.
+		config.URL = "ignored.json"
+		assert.Equal(t, w1.Body.String(), performRequest(http.MethodGet, "/swagger-initializer.js", router).Body.String())
+
+		handler.Reconfigure(URL("second.json"), DeepLinking(false))
+		assert.Equal(t, "second.json", handler.Config().URL)
+		assert.Equal(t, swag.Name, handler.Config().InstanceName)
+
+		w2 := performRequest(http.MethodGet, "/swagger-initializer.js", router)
+		assert.Contains(t, w2.Body.String(), `"url":"second.json"`)
+		assert.Contains(t, w2.Body.String(), `"deepLinking":false`)
+		assert.NotEqual(t, w1.Header().Get("ETag"), w2.Header().Get("ETag"))
+	})
+}
+
+func TestHandlerRenderError(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/*any", wrapFS(nil, UseRenderer(failingRenderer{})))
+
+		assert.Equal(t, http.StatusInternalServerError, performRequest(http.MethodGet, "/index.html", router).Code)
+	})
+}
+
+type failingRenderer struct{}
+
+func (failingRenderer) Pages() []string {
+	return []string{"index.html"}
+}
+
+func (failingRenderer) Render(string, *Config) ([]byte, error) {
+	return nil, errors.New("render failed")
+}
+
+func (failingRenderer) Assets() fs.FS {
+	return nil
+}
+
+// BenchmarkRenderIndex measures rendering index.html on every request, as done before pages were cached.
+func BenchmarkRenderIndex(b *testing.B) {
+	renderer := newSwaggerUIRenderer(swaggerFilesV2.FS)
+	config := &Config{URL: "doc.json", Title: "Swagger UI"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = renderer.Render("index.html", config)
+		}
+	})
+}
+
+func BenchmarkServeIndex(b *testing.B) {
+	benchmarkServe(b, "/index.html")
+}
+
+func BenchmarkServeInitializer(b *testing.B) {
+	benchmarkServe(b, "/swagger-initializer.js")
+}
+
+func benchmarkServe(b *testing.B, target string) {
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.GET("/*any", wrapFS(swaggerFilesV2.FS))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+
+		for pb.Next() {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			if w.Code != http.StatusOK {
+				b.Fatalf("unexpected status %d", w.Code)
+			}
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package ginSwagger
+
+import (
+	"net/http"
+	"os"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Toggle switches the documentation on and off at runtime, e.g. from an admin endpoint,
+// a configuration reload or a feature-flag provider. Pass its Enabled method to the
+// Enabled option. A Toggle is safe for concurrent use.
+type Toggle struct {
+	disabled atomic.Bool
+}
+
+// NewToggle returns a Toggle in the given state.
+func NewToggle(enabled bool) *Toggle {
+	t := &Toggle{}
+	t.Set(enabled)
+
+	return t
+}
+
+// Enabled reports whether the documentation is currently served.
+func (t *Toggle) Enabled() bool {
+	return !t.disabled.Load()
+}
+
+// Set switches the documentation on or off.
+func (t *Toggle) Set(enabled bool) {
+	t.disabled.Store(!enabled)
+}
+
+// Enable switches the documentation on.
+func (t *Toggle) Enable() {
+	t.Set(true)
+}
+
+// Disable switches the documentation off.
+func (t *Toggle) Disable() {
+	t.Set(false)
+}
+
+// EnvDisabled returns a predicate for the Enabled option that turns the documentation off
+// while the environment variable envName is set to a non-empty value. Unlike
+// DisablingWrapHandler used to, it reads the variable on every request.
+func EnvDisabled(envName string) func() bool {
+	return func() bool {
+		return os.Getenv(envName) == ""
+	}
+}
+
+// enabled reports whether the documentation is served for the request,
+// writing the disabled response when it is not.
+func (config *Config) enabled(ctx *gin.Context) bool {
+	if config.Enabled == nil || config.Enabled() {
+		return true
+	}
+
+	status := config.DisabledStatus
+	if status == 0 {
+		status = http.StatusNotFound
+	}
+
+	ctx.String(status, "")
+	ctx.Abort()
+
+	return false
+}
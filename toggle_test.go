@@ -0,0 +1,110 @@
+package ginSwagger
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	swaggerFilesV2 "github.com/swaggo/files/v2"
+)
+
+func TestToggle(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		toggle := NewToggle(true)
+
+		router.GET("/*any", wrapFS(swaggerFilesV2.FS, InstanceName("validate"), Enabled(toggle.Enabled)))
+
+		for _, target := range []string{"/index.html", "/swagger-ui.css", "/doc.json"} {
+			assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, target, router).Code, target)
+		}
+
+		toggle.Disable()
+		assert.False(t, toggle.Enabled())
+
+		for _, target := range []string{"/index.html", "/swagger-ui.css", "/doc.json"} {
+			w := performRequest(http.MethodGet, target, router)
+			assert.Equal(t, http.StatusNotFound, w.Code, target)
+			assert.Empty(t, w.Body.String(), target)
+		}
+
+		toggle.Enable()
+		assert.True(t, toggle.Enabled())
+		assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/index.html", router).Code)
+	})
+}
+
+func TestToggleDisabledStatus(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		toggle := NewToggle(false)
+
+		// Disabled documentation is hidden before guards run.
+		router.GET("/*any", wrapFS(swaggerFilesV2.FS,
+			Enabled(toggle.Enabled),
+			DisabledStatus(http.StatusServiceUnavailable),
+			Guards(BearerGuard("token"))))
+
+		assert.Equal(t, http.StatusServiceUnavailable, performRequest(http.MethodGet, "/index.html", router).Code)
+
+		toggle.Set(true)
+		assert.Equal(t, http.StatusUnauthorized, performRequest(http.MethodGet, "/index.html", router).Code)
+	})
+}
+
+func TestToggleConcurrentUse(t *testing.T) {
+	toggle := NewToggle(true)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func(enabled bool) {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				toggle.Set(enabled)
+				_ = toggle.Enabled()
+			}
+		}(i%2 == 0)
+	}
+
+	wg.Wait()
+}
+
+func TestEnvDisabled(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		const envName = "SWAGGER_DISABLE_RUNTIME"
+
+		router.GET("/*any", disablingWrapHandler(nil, envName))
+
+		assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/index.html", router).Code)
+
+		t.Setenv(envName, "true")
+		assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/index.html", router).Code)
+
+		t.Setenv(envName, "")
+		assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/index.html", router).Code)
+	})
+}
+
+func TestEnabledOptions(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.Enabled)
+
+	Enabled(EnvDisabled("SWAGGER_DISABLE_OPTION"))(&cfg)
+	assert.True(t, cfg.Enabled())
+
+	DisabledStatus(http.StatusForbidden)(&cfg)
+	assert.Equal(t, http.StatusForbidden, cfg.DisabledStatus)
+}
@@ -0,0 +1,92 @@
+package ginSwagger
+
+import (
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+	"github.com/swaggo/swag"
+)
+
+// DocTransformer modifies the parsed swagger document before it is served.
+// The document is the decoded JSON of swag.ReadDoc and may be changed in place.
+type DocTransformer func(ctx *gin.Context, doc map[string]interface{}) error
+
+// docSource produces the document served by the handler.
+type docSource func() (string, error)
+
+// instanceSource reads the document of a registered swag instance.
+func instanceSource(instanceName string) docSource {
+	return func() (string, error) {
+		return swag.ReadDoc(instanceName)
+	}
+}
+
+// readDoc reads the document from source and runs it through the transformers.
+// Without transformers the document is returned verbatim.
+func readDoc(ctx *gin.Context, source docSource, transformers []DocTransformer) (string, error) {
+	doc, err := source()
+	if err != nil || len(transformers) == 0 {
+		return doc, err
+	}
+
+	var spec map[string]interface{}
+	if err := json.Unmarshal([]byte(doc), &spec); err != nil {
+		return "", err
+	}
+
+	for _, transform := range transformers {
+		if err := transform(ctx, spec); err != nil {
+			return "", err
+		}
+	}
+
+	body, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+
+	return string(body), nil
+}
+
+// StripOperations returns a DocTransformer that removes every operation carrying
+// the given vendor extension with a true value, e.g. "x-internal".
+// Paths left without operations are removed as well.
+func StripOperations(extension string) DocTransformer {
+	return func(_ *gin.Context, doc map[string]interface{}) error {
+		paths, _ := doc["paths"].(map[string]interface{})
+
+		for path, node := range paths {
+			item, ok := node.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			for _, method := range httpMethods {
+				operation, ok := item[method].(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				if flag, _ := operation[extension].(bool); flag {
+					delete(item, method)
+				}
+			}
+
+			if !hasOperations(item) {
+				delete(paths, path)
+			}
+		}
+
+		return nil
+	}
+}
+
+func hasOperations(item map[string]interface{}) bool {
+	for _, method := range httpMethods {
+		if _, ok := item[method]; ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,103 @@
+package ginSwagger
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	swaggerFiles "github.com/swaggo/files"
+	"github.com/swaggo/swag"
+)
+
+type internalSwag struct{}
+
+func (s *internalSwag) ReadDoc() string {
+	return `{
+    "swagger": "2.0",
+    "info": {"title": "internal", "version": "1.0"},
+    "paths": {
+        "/public": {"get": {"responses": {"200": {"description": "ok"}}}},
+        "/mixed": {
+            "get": {"responses": {"200": {"description": "ok"}}},
+            "delete": {"x-internal": true, "responses": {"204": {"description": "deleted"}}}
+        },
+        "/admin": {"post": {"x-internal": true, "responses": {"200": {"description": "ok"}}}}
+    }
+}`
+}
+
+func init() {
+	swag.Register("internal", &internalSwag{})
+}
+
+func TestDocTransformers(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		addHost := func(ctx *gin.Context, doc map[string]interface{}) error {
+			doc["host"] = ctx.Request.Host
+			doc["x-environment"] = "staging"
+
+			return nil
+		}
+
+		router.GET("/docs/*any", wrapHandler(swaggerFiles.Handler,
+			InstanceName("internal"),
+			OpenAPI3(true),
+			DocTransformers(StripOperations("x-internal"), addHost)))
+
+		w := performRequest(http.MethodGet, "/docs/doc.json", router)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var doc map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+		assert.Equal(t, "example.com", doc["host"])
+		assert.Equal(t, "staging", doc["x-environment"])
+
+		paths := doc["paths"].(map[string]interface{})
+		assert.Contains(t, paths, "/public")
+		assert.NotContains(t, paths, "/admin")
+		assert.Contains(t, paths["/mixed"], "get")
+		assert.NotContains(t, paths["/mixed"], "delete")
+
+		w = performRequest(http.MethodGet, "/docs/doc.yaml", router)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "x-environment: staging")
+		assert.NotContains(t, w.Body.String(), "/admin")
+
+		w = performRequest(http.MethodGet, "/docs/openapi.json", router)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"url":"https://example.com"`)
+		assert.NotContains(t, w.Body.String(), "/admin")
+	})
+}
+
+func TestDocTransformersError(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		failing := func(*gin.Context, map[string]interface{}) error {
+			return errors.New("boom")
+		}
+
+		router.GET("/*any", wrapHandler(swaggerFiles.Handler, InstanceName("internal"), DocTransformers(failing)))
+
+		assert.Equal(t, http.StatusInternalServerError, performRequest(http.MethodGet, "/doc.json", router).Code)
+	})
+}
+
+func TestDocTransformersOption(t *testing.T) {
+	var cfg Config
+	assert.Empty(t, cfg.DocTransformers)
+
+	noop := func(*gin.Context, map[string]interface{}) error { return nil }
+
+	DocTransformers(noop)(&cfg)
+	DocTransformers(noop, StripOperations("x-internal"))(&cfg)
+	assert.Len(t, cfg.DocTransformers, 3)
+}
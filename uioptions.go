@@ -0,0 +1,104 @@
+package ginSwagger
+
+import (
+	"encoding/json"
+)
+
+// Values accepted by the enumerated Swagger UI options.
+var (
+	submitMethods         = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+	modelRenderings       = []string{"example", "model"}
+	operationsSorters     = []string{"alpha", "method"}
+	tagsSorters           = []string{"alpha"}
+	syntaxHighlightThemes = []string{"agate", "arta", "monokai", "nord", "obsidian", "tomorrow-night", "idea"}
+)
+
+// uiOptions is the configuration object passed to SwaggerUIBundle.
+// Options left at their zero value, or nil for options whose zero value differs from the
+// default of Swagger UI, are omitted, so Swagger UI applies its own default.
+type uiOptions struct {
+	URL                      string           `json:"url,omitempty"`
+	URLs                     []specURL        `json:"urls,omitempty"`
+	PrimaryName              string           `json:"urls.primaryName,omitempty"`
+	ValidatorURL             *string          `json:"validatorUrl"`
+	PersistAuthorization     bool             `json:"persistAuthorization"`
+	DocExpansion             string           `json:"docExpansion,omitempty"`
+	DeepLinking              bool             `json:"deepLinking"`
+	DefaultModelsExpandDepth int              `json:"defaultModelsExpandDepth"`
+	DefaultModelExpandDepth  *int             `json:"defaultModelExpandDepth,omitempty"`
+	DefaultModelRendering    string           `json:"defaultModelRendering,omitempty"`
+	Filter                   interface{}      `json:"filter,omitempty"`
+	MaxDisplayedTags         int              `json:"maxDisplayedTags,omitempty"`
+	OperationsSorter         string           `json:"operationsSorter,omitempty"`
+	TagsSorter               string           `json:"tagsSorter,omitempty"`
+	TryItOutEnabled          bool             `json:"tryItOutEnabled,omitempty"`
+	SupportedSubmitMethods   []string         `json:"supportedSubmitMethods,omitempty"`
+	DisplayRequestDuration   bool             `json:"displayRequestDuration,omitempty"`
+	DisplayOperationID       bool             `json:"displayOperationId,omitempty"`
+	ShowExtensions           bool             `json:"showExtensions,omitempty"`
+	ShowCommonExtensions     bool             `json:"showCommonExtensions,omitempty"`
+	SyntaxHighlight          *syntaxHighlight `json:"syntaxHighlight,omitempty"`
+	WithCredentials          bool             `json:"withCredentials,omitempty"`
+	RequestSnippetsEnabled   bool             `json:"requestSnippetsEnabled,omitempty"`
+	ShowMutatedRequest       *bool            `json:"showMutatedRequest,omitempty"`
+}
+
+type syntaxHighlight struct {
+	Theme string `json:"theme"`
+}
+
+// swaggerUIOptions renders the SwaggerUIBundle options for the UI loading the spec from url as JSON.
+// JSON encoding escapes the values, so they cannot break out of the initializer script.
+func (config Config) swaggerUIOptions(url string) string {
+	options := uiOptions{
+		PersistAuthorization:     config.PersistAuthorization,
+		DocExpansion:             config.DocExpansion,
+		DeepLinking:              config.DeepLinking,
+		DefaultModelsExpandDepth: config.DefaultModelsExpandDepth,
+		DefaultModelExpandDepth:  config.DefaultModelExpandDepth,
+		DefaultModelRendering:    config.DefaultModelRendering,
+		MaxDisplayedTags:         config.MaxDisplayedTags,
+		OperationsSorter:         config.OperationsSorter,
+		TagsSorter:               config.TagsSorter,
+		TryItOutEnabled:          config.TryItOutEnabled,
+		SupportedSubmitMethods:   config.SupportedSubmitMethods,
+		DisplayRequestDuration:   config.DisplayRequestDuration,
+		DisplayOperationID:       config.DisplayOperationID,
+		ShowExtensions:           config.ShowExtensions,
+		ShowCommonExtensions:     config.ShowCommonExtensions,
+		WithCredentials:          config.WithCredentials,
+		RequestSnippetsEnabled:   config.RequestSnippetsEnabled,
+		ShowMutatedRequest:       config.ShowMutatedRequest,
+	}
+
+	if len(config.Specs) == 0 {
+		options.URL = url
+	} else {
+		options.URLs = specURLs(config.Specs)
+
+		options.PrimaryName = config.PrimarySpec
+		if options.PrimaryName == "" {
+			options.PrimaryName = config.Specs[0].displayName()
+		}
+	}
+
+	// An empty ValidatorURL keeps the validator badge disabled.
+	if config.ValidatorURL != "" {
+		options.ValidatorURL = &config.ValidatorURL
+	}
+
+	switch {
+	case config.FilterExpression != "":
+		options.Filter = config.FilterExpression
+	case config.Filter:
+		options.Filter = true
+	}
+
+	if config.SyntaxHighlightTheme != "" {
+		options.SyntaxHighlight = &syntaxHighlight{Theme: config.SyntaxHighlightTheme}
+	}
+
+	body, _ := json.Marshal(options)
+
+	return string(body)
+}
@@ -0,0 +1,189 @@
+package ginSwagger
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	swaggerFilesV2 "github.com/swaggo/files/v2"
+)
+
+// initializerOptions extracts the options passed to SwaggerUIBundle from swagger-initializer.js.
+func initializerOptions(t *testing.T, body string) map[string]interface{} {
+	const start = "SwaggerUIBundle(Object.assign("
+
+	index := strings.Index(body, start)
+	require.NotEqual(t, -1, index)
+
+	var options map[string]interface{}
+	require.NoError(t, json.NewDecoder(strings.NewReader(body[index+len(start):])).Decode(&options))
+
+	return options
+}
+
+func TestSwaggerUIOptionsDefaults(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/*any", wrapFS(swaggerFilesV2.FS))
+
+		w := performRequest(http.MethodGet, "/swagger-initializer.js", router)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, map[string]interface{}{
+			"url":                      "doc.json",
+			"validatorUrl":             nil,
+			"persistAuthorization":     false,
+			"docExpansion":             "list",
+			"deepLinking":              true,
+			"defaultModelsExpandDepth": float64(1),
+		}, initializerOptions(t, w.Body.String()))
+	})
+}
+
+func TestSwaggerUIOptionsLiteralConfig(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/*any", customWrapFS(&Config{URL: "doc.json"}, swaggerFilesV2.FS))
+
+		options := initializerOptions(t, performRequest(http.MethodGet, "/swagger-initializer.js", router).Body.String())
+		assert.NotContains(t, options, "defaultModelExpandDepth")
+		assert.NotContains(t, options, "showMutatedRequest")
+
+		router = gin.New()
+		router.GET("/*any", wrapFS(swaggerFilesV2.FS, DefaultModelExpandDepth(0), ShowMutatedRequest(false)))
+
+		options = initializerOptions(t, performRequest(http.MethodGet, "/swagger-initializer.js", router).Body.String())
+		assert.Equal(t, float64(0), options["defaultModelExpandDepth"])
+		assert.Equal(t, false, options["showMutatedRequest"])
+	})
+}
+
+func TestSwaggerUIOptions(t *testing.T) {
+	forEachAdapter(t, func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		router.GET("/*any", wrapFS(swaggerFilesV2.FS,
+			Filter(true),
+			TryItOutEnabled(true),
+			SupportedSubmitMethods("get", "post"),
+			DisplayRequestDuration(true),
+			DisplayOperationID(true),
+			ShowExtensions(true),
+			ShowCommonExtensions(true),
+			DefaultModelRendering("model"),
+			DefaultModelExpandDepth(3),
+			MaxDisplayedTags(10),
+			OperationsSorter("method"),
+			TagsSorter("alpha"),
+			SyntaxHighlightTheme("monokai"),
+			ValidatorURL("https://validator.swagger.io/validator"),
+			WithCredentials(true),
+			RequestSnippetsEnabled(true),
+			ShowMutatedRequest(false),
+		))
+
+		options := initializerOptions(t, performRequest(http.MethodGet, "/swagger-initializer.js", router).Body.String())
+
+		for key, expected := range map[string]interface{}{
+			"filter":                  true,
+			"tryItOutEnabled":         true,
+			"supportedSubmitMethods":  []interface{}{"get", "post"},
+			"displayRequestDuration":  true,
+			"displayOperationId":      true,
+			"showExtensions":          true,
+			"showCommonExtensions":    true,
+			"defaultModelRendering":   "model",
+			"defaultModelExpandDepth": float64(3),
+			"maxDisplayedTags":        float64(10),
+			"operationsSorter":        "method",
+			"tagsSorter":              "alpha",
+			"syntaxHighlight":         map[string]interface{}{"theme": "monokai"},
+			"validatorUrl":            "https://validator.swagger.io/validator",
+			"withCredentials":         true,
+			"requestSnippetsEnabled":  true,
+			"showMutatedRequest":      false,
+		} {
+			assert.Equal(t, expected, options[key], key)
+		}
+	})
+}
+
+func TestSwaggerUIOptionsEscaping(t *testing.T) {
+	cfg := Config{URL: `doc.json"});alert(1);</script>`, FilterExpression: "pets\u2028"}
+
+	options := cfg.swaggerUIOptions(cfg.URL)
+	assert.NotContains(t, options, `</script>`)
+	assert.NotContains(t, options, "\u2028")
+
+	var decoded uiOptions
+	require.NoError(t, json.Unmarshal([]byte(options), &decoded))
+	assert.Equal(t, cfg.URL, decoded.URL)
+	assert.Equal(t, "pets\u2028", decoded.Filter)
+}
+
+func TestFilter(t *testing.T) {
+	var cfg Config
+	assert.NotContains(t, cfg.swaggerUIOptions(""), `"filter"`)
+
+	configFunc := Filter(true)
+	configFunc(&cfg)
+	assert.Equal(t, true, cfg.Filter)
+	assert.Contains(t, cfg.swaggerUIOptions(""), `"filter":true`)
+
+	configFunc = FilterExpression("pet")
+	configFunc(&cfg)
+	assert.Equal(t, "pet", cfg.FilterExpression)
+	assert.Contains(t, cfg.swaggerUIOptions(""), `"filter":"pet"`)
+}
+
+func TestSwaggerUIOptionFuncs(t *testing.T) {
+	var cfg Config
+
+	TryItOutEnabled(true)(&cfg)
+	SupportedSubmitMethods("get")(&cfg)
+	DisplayRequestDuration(true)(&cfg)
+	DisplayOperationID(true)(&cfg)
+	ShowExtensions(true)(&cfg)
+	ShowCommonExtensions(true)(&cfg)
+	DefaultModelRendering("model")(&cfg)
+	DefaultModelExpandDepth(2)(&cfg)
+	MaxDisplayedTags(5)(&cfg)
+	OperationsSorter("alpha")(&cfg)
+	TagsSorter("alpha")(&cfg)
+	SyntaxHighlightTheme("nord")(&cfg)
+	ValidatorURL("https://validator.example.com")(&cfg)
+	WithCredentials(true)(&cfg)
+	RequestSnippetsEnabled(true)(&cfg)
+	ShowMutatedRequest(true)(&cfg)
+
+	assert.Equal(t, Config{
+		TryItOutEnabled:         true,
+		SupportedSubmitMethods:  []string{"get"},
+		DisplayRequestDuration:  true,
+		DisplayOperationID:      true,
+		ShowExtensions:          true,
+		ShowCommonExtensions:    true,
+		DefaultModelRendering:   "model",
+		DefaultModelExpandDepth: intPtr(2),
+		MaxDisplayedTags:        5,
+		OperationsSorter:        "alpha",
+		TagsSorter:              "alpha",
+		SyntaxHighlightTheme:    "nord",
+		ValidatorURL:            "https://validator.example.com",
+		WithCredentials:         true,
+		RequestSnippetsEnabled:  true,
+		ShowMutatedRequest:      boolPtr(true),
+	}, cfg)
+}
+
+func intPtr(i int) *int { return &i }
+
+func boolPtr(b bool) *bool { return &b }
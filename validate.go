@@ -0,0 +1,222 @@
+package ginSwagger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/swaggo/swag"
+)
+
+// docExpansions are the values Swagger UI accepts for docExpansion.
+var docExpansions = []string{"list", "full", "none"}
+
+// ConfigError reports an invalid Config field.
+type ConfigError struct {
+	// Field is the name of the Config field, e.g. "DocExpansion" or "Specs[1].InstanceName".
+	Field string
+	Err   error
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("ginSwagger: invalid Config.%s: %v", e.Field, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+func invalidField(field, format string, args ...interface{}) error {
+	return &ConfigError{Field: field, Err: fmt.Errorf(format, args...)}
+}
+
+// Validate checks every field of the configuration and that the swag instances it
+// refers to are registered and hold valid JSON documents. All problems are reported
+// at once, joined into one error of *ConfigError values.
+func (config Config) Validate() error {
+	var errs []error
+
+	if config.URL == "" && len(config.Specs) == 0 {
+		errs = append(errs, invalidField("URL", "must not be empty"))
+	} else if config.URL != "" {
+		if err := validateURL(config.URL); err != nil {
+			errs = append(errs, &ConfigError{Field: "URL", Err: err})
+		}
+	}
+
+	for _, enum := range []struct {
+		field, value string
+		allowed      []string
+	}{
+		{"DocExpansion", config.DocExpansion, docExpansions},
+		{"DefaultModelRendering", config.DefaultModelRendering, modelRenderings},
+		{"OperationsSorter", config.OperationsSorter, operationsSorters},
+		{"TagsSorter", config.TagsSorter, tagsSorters},
+		{"SyntaxHighlightTheme", config.SyntaxHighlightTheme, syntaxHighlightThemes},
+	} {
+		if enum.value != "" && !containsString(enum.allowed, enum.value) {
+			errs = append(errs, invalidField(enum.field, "%q is not one of %s", enum.value, strings.Join(enum.allowed, ", ")))
+		}
+	}
+
+	for i, method := range config.SupportedSubmitMethods {
+		if !containsString(submitMethods, method) {
+			errs = append(errs, invalidField(fmt.Sprintf("SupportedSubmitMethods[%d]", i), "%q is not one of %s",
+				method, strings.Join(submitMethods, ", ")))
+		}
+	}
+
+	if config.DefaultModelsExpandDepth < -1 {
+		errs = append(errs, invalidField("DefaultModelsExpandDepth", "%d is below -1", config.DefaultModelsExpandDepth))
+	}
+
+	if config.DefaultModelExpandDepth != nil && *config.DefaultModelExpandDepth < 0 {
+		errs = append(errs, invalidField("DefaultModelExpandDepth", "%d is negative", *config.DefaultModelExpandDepth))
+	}
+
+	if config.MaxDisplayedTags < 0 {
+		errs = append(errs, invalidField("MaxDisplayedTags", "%d is negative", config.MaxDisplayedTags))
+	}
+
+	if config.ValidatorURL != "" {
+		if err := validateURL(config.ValidatorURL); err != nil {
+			errs = append(errs, &ConfigError{Field: "ValidatorURL", Err: err})
+		}
+	}
+
+	if config.Oauth2RedirectURL != "" {
+		if err := validateURL(config.Oauth2RedirectURL); err != nil {
+			errs = append(errs, &ConfigError{Field: "Oauth2RedirectURL", Err: err})
+		}
+	}
+
+	if config.SecurityHeaders != nil && config.SecurityHeaders.ReportURI != "" {
+		if err := validateURL(config.SecurityHeaders.ReportURI); err != nil {
+			errs = append(errs, &ConfigError{Field: "SecurityHeaders.ReportURI", Err: err})
+		}
+	}
+
+	switch config.GuardDenyStatus {
+	case 0, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+	default:
+		errs = append(errs, invalidField("GuardDenyStatus", "%d is not one of 401, 403, 404", config.GuardDenyStatus))
+	}
+
+	if config.DisabledStatus != 0 && (config.DisabledStatus < 400 || config.DisabledStatus > 599) {
+		errs = append(errs, invalidField("DisabledStatus", "%d is not a client or server error status", config.DisabledStatus))
+	}
+
+	if config.CORS != nil {
+		for i, origin := range config.CORS.AllowOrigins {
+			if origin != "*" && urlOrigin(origin) != origin {
+				errs = append(errs, invalidField(fmt.Sprintf("CORS.AllowOrigins[%d]", i), "%q is neither \"*\" nor an http(s) origin", origin))
+			}
+		}
+
+		if config.CORS.AllowCredentials && containsString(config.CORS.AllowOrigins, "*") {
+			errs = append(errs, invalidField("CORS.AllowCredentials", "credentials cannot be shared with any origin \"*\""))
+		}
+
+		if config.CORS.MaxAge < 0 {
+			errs = append(errs, invalidField("CORS.MaxAge", "%v is negative", config.CORS.MaxAge))
+		}
+	}
+
+	switch config.RedirectStatus {
+	case 0, http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+	default:
+		errs = append(errs, invalidField("RedirectStatus", "%d is not one of 301, 302, 303, 307, 308", config.RedirectStatus))
+	}
+
+	for i, proxy := range config.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				errs = append(errs, invalidField(fmt.Sprintf("TrustedProxies[%d]", i), "%q is neither an IP nor a CIDR", proxy))
+			}
+		}
+	}
+
+	errs = append(errs, config.validateSpecs()...)
+
+	if len(config.MergeInstances) == 0 {
+		if err := validateInstance(config.InstanceName); err != nil {
+			errs = append(errs, &ConfigError{Field: "InstanceName", Err: err})
+		}
+	} else if _, err := MergeDocs(config.MergeRenamer, config.MergeInstances...); err != nil {
+		errs = append(errs, &ConfigError{Field: "MergeInstances", Err: err})
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateSpecs checks the spec selector entries and their instances.
+func (config Config) validateSpecs() []error {
+	var (
+		errs      []error
+		instances = map[string]bool{}
+		names     = map[string]bool{}
+	)
+
+	for i, spec := range config.Specs {
+		field := fmt.Sprintf("Specs[%d]", i)
+
+		if instances[spec.InstanceName] {
+			errs = append(errs, invalidField(field+".InstanceName", "%q is listed twice", spec.InstanceName))
+		} else if err := validateInstance(spec.InstanceName); err != nil {
+			errs = append(errs, &ConfigError{Field: field + ".InstanceName", Err: err})
+		}
+
+		if names[spec.displayName()] {
+			errs = append(errs, invalidField(field+".Name", "%q is listed twice", spec.displayName()))
+		}
+
+		instances[spec.InstanceName] = true
+		names[spec.displayName()] = true
+	}
+
+	if config.PrimarySpec != "" && !names[config.PrimarySpec] {
+		errs = append(errs, invalidField("PrimarySpec", "%q is not the name of a spec", config.PrimarySpec))
+	}
+
+	return errs
+}
+
+// validateURL accepts relative references and absolute http(s) URLs.
+func validateURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	if parsed.Scheme != "" && parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %q", parsed.Scheme)
+	}
+
+	return nil
+}
+
+// validateInstance checks that the swag instance is registered and its document is valid JSON.
+func validateInstance(instanceName string) error {
+	if instanceName == "" {
+		return errors.New("must not be empty")
+	}
+
+	if swag.GetSwagger(instanceName) == nil {
+		return fmt.Errorf("swag instance %q is not registered", instanceName)
+	}
+
+	doc, err := swag.ReadDoc(instanceName)
+	if err != nil {
+		return err
+	}
+
+	if !json.Valid([]byte(doc)) {
+		return fmt.Errorf("document of swag instance %q is not valid JSON", instanceName)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package ginSwagger
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	swaggerFilesV2 "github.com/swaggo/files/v2"
+	"github.com/swaggo/swag"
+)
+
+func init() {
+	swag.Register("validate", rawSwag(`{"swagger": "2.0", "info": {"title": "Valid", "version": "1.0"}, "paths": {}}`))
+	swag.Register("validate_broken", rawSwag(`{"swagger": "2.0",`))
+}
+
+func validConfig() Config {
+	return Config{
+		URL:                      "doc.json",
+		DocExpansion:             "list",
+		InstanceName:             "validate",
+		DefaultModelsExpandDepth: 1,
+	}
+}
+
+func configErrorFields(err error) []string {
+	var fields []string
+
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var configErr *ConfigError
+		if errors.As(err, &configErr) {
+			fields = append(fields, configErr.Field)
+		}
+	}
+
+	return fields
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, validConfig().Validate())
+
+	for name, tc := range map[string]struct {
+		option func(*Config)
+		field  string
+	}{
+		"empty url":                        {URL(""), "URL"},
+		"javascript url":                   {URL("javascript:alert(1)"), "URL"},
+		"unknown doc expansion":            {DocExpansion("all"), "DocExpansion"},
+		"depth below -1":                   {DefaultModelsExpandDepth(-2), "DefaultModelsExpandDepth"},
+		"negative model depth":             {DefaultModelExpandDepth(-1), "DefaultModelExpandDepth"},
+		"negative max tags":                {MaxDisplayedTags(-1), "MaxDisplayedTags"},
+		"unknown rendering":                {DefaultModelRendering("schema"), "DefaultModelRendering"},
+		"unknown sorter":                   {OperationsSorter("path"), "OperationsSorter"},
+		"unknown tags sorter":              {TagsSorter("method"), "TagsSorter"},
+		"unknown theme":                    {SyntaxHighlightTheme("solarized"), "SyntaxHighlightTheme"},
+		"unknown submit method":            {SupportedSubmitMethods("get", "POST"), "SupportedSubmitMethods[1]"},
+		"invalid validator url":            {ValidatorURL("file:///validator"), "ValidatorURL"},
+		"invalid redirect url":             {Oauth2RedirectURL("javascript:void(0)"), "Oauth2RedirectURL"},
+		"unknown deny status":              {GuardDenyStatus(http.StatusTeapot), "GuardDenyStatus"},
+		"successful disabled":              {DisabledStatus(http.StatusNoContent), "DisabledStatus"},
+		"non-redirect status":              {RedirectStatus(http.StatusOK), "RedirectStatus"},
+		"invalid report uri":               {SecurityHeaders(SecurityPolicy{ReportURI: "data:,"}), "SecurityHeaders.ReportURI"},
+		"cors origin with path":            {CORS(CORSPolicy{AllowOrigins: []string{"*", "https://portal.example.com/"}}), "CORS.AllowOrigins[1]"},
+		"cors credentials with any origin": {CORS(CORSPolicy{AllowOrigins: []string{"*"}, AllowCredentials: true}), "CORS.AllowCredentials"},
+		"negative cors max age":            {CORS(CORSPolicy{MaxAge: -time.Second}), "CORS.MaxAge"},
+		"unregistered instance":            {InstanceName("validate_missing"), "InstanceName"},
+		"invalid document":                 {InstanceName("validate_broken"), "InstanceName"},
+		"untrusted proxy":                  {RewriteHost("10.0.0.0/8", "proxy.local"), "TrustedProxies[1]"},
+		"merge conflict":                   {MergeInstances(nil, "merge_users", "merge_orders"), "MergeInstances"},
+		"unknown primary spec":             {PrimarySpec("v3"), "PrimarySpec"},
+		"unregistered spec": {
+			Specs(Spec{InstanceName: "validate"}, Spec{InstanceName: "validate_missing"}),
+			"Specs[1].InstanceName",
+		},
+		"duplicate spec": {
+			Specs(Spec{InstanceName: "validate"}, Spec{Name: "other", InstanceName: "validate"}),
+			"Specs[1].InstanceName",
+		},
+		"duplicate spec name": {
+			Specs(Spec{Name: "v1", InstanceName: "validate"}, Spec{Name: "v1", InstanceName: "merge_users"}),
+			"Specs[1].Name",
+		},
+	} {
+		config := validConfig()
+		tc.option(&config)
+
+		err := config.Validate()
+		require.Error(t, err, name)
+		assert.Equal(t, []string{tc.field}, configErrorFields(err), name)
+	}
+
+	valid := validConfig()
+	MergeInstances(PrefixRenamer, "merge_users", "merge_orders")(&valid)
+	Specs(Spec{Name: "v1", InstanceName: "validate"}, Spec{InstanceName: "merge_users"})(&valid)
+	PrimarySpec("merge_users")(&valid)
+	RewriteHost("10.0.0.1", "10.0.0.0/8", "::1")(&valid)
+	URL("https://example.com/doc.json")(&valid)
+	DocExpansion("")(&valid)
+	DefaultModelsExpandDepth(-1)(&valid)
+	assert.NoError(t, valid.Validate())
+}
+
+func TestValidateReportsAllFields(t *testing.T) {
+	config := Config{URL: "ftp://example.com/doc.json", DocExpansion: "open", InstanceName: "validate_missing"}
+
+	err := config.Validate()
+	require.Error(t, err)
+	assert.Equal(t, []string{"URL", "DocExpansion", "InstanceName"}, configErrorFields(err))
+	assert.Contains(t, err.Error(), `ginSwagger: invalid Config.DocExpansion: "open" is not one of list, full, none`)
+	assert.Contains(t, err.Error(), `ginSwagger: invalid Config.InstanceName: swag instance "validate_missing" is not registered`)
+}
+
+func TestNew(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	config := validConfig()
+
+	handler, err := New(&config, swaggerFilesV2.FS)
+	require.NoError(t, err)
+
+	router.GET("/*any", handler)
+
+	assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/index.html", router).Code)
+	assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/doc.json", router).Code)
+
+	config.DocExpansion = "everything"
+
+	handler, err = New(&config, swaggerFilesV2.FS)
+	assert.Nil(t, handler)
+	assert.EqualError(t, err, `ginSwagger: invalid Config.DocExpansion: "everything" is not one of list, full, none`)
+}
+
+func TestNewRenderError(t *testing.T) {
+	config := validConfig()
+	config.Renderer = failingRenderer{}
+
+	handler, err := New(&config, nil)
+	assert.Nil(t, handler)
+	assert.EqualError(t, err, "ginSwagger: rendering index.html: render failed")
+}
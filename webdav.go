@@ -0,0 +1,32 @@
+package ginSwagger
+
+import (
+	"context"
+	"io/fs"
+	"os"
+
+	"golang.org/x/net/webdav"
+)
+
+// webdavFS exposes the file system of a webdav handler as an fs.FS,
+// so the handlers of older releases keep working on top of WrapFS.
+type webdavFS struct {
+	fs webdav.FileSystem
+}
+
+// webdavAssets returns the assets of a webdav handler, or nil if there is none.
+func webdavAssets(handler *webdav.Handler) fs.FS {
+	if handler == nil || handler.FileSystem == nil {
+		return nil
+	}
+
+	return webdavFS{fs: handler.FileSystem}
+}
+
+func (w webdavFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	return w.fs.OpenFile(context.Background(), "/"+name, os.O_RDONLY, 0)
+}
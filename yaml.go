@@ -0,0 +1,88 @@
+package ginSwagger
+
+import (
+	"bytes"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// jsonToYAML converts a JSON swagger document into YAML, keeping the original key order.
+func jsonToYAML(doc string) ([]byte, error) {
+	var node yaml.Node
+
+	// JSON is a subset of YAML, apart from the \/ escape, so the document can be parsed as-is.
+	if err := yaml.Unmarshal(unescapeSolidus(doc), &node); err != nil {
+		return nil, err
+	}
+
+	resetStyle(&node)
+
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// unescapeSolidus replaces the JSON escape \/, which YAML does not know, with a plain /.
+// Backslashes only occur within JSON strings, so escapes can be recognised without parsing.
+func unescapeSolidus(doc string) []byte {
+	out := make([]byte, 0, len(doc))
+
+	for i := 0; i < len(doc); i++ {
+		if doc[i] != '\\' || i+1 == len(doc) {
+			out = append(out, doc[i])
+
+			continue
+		}
+
+		if doc[i+1] != '/' {
+			out = append(out, doc[i])
+		}
+
+		out = append(out, doc[i+1])
+		i++
+	}
+
+	return out
+}
+
+// yaml11Scalar matches the plain scalars that YAML 1.1 parsers, still common in tooling, resolve
+// to a type other than string: booleans, integers and floats (with _ separators, binary, octal,
+// hexadecimal and sexagesimal forms), infinities, NaN, nulls, timestamps, and the merge and value
+// keys. yaml.v3 emits YAML 1.2 and only quotes the strings that YAML 1.2 would not read back.
+var yaml11Scalar = regexp.MustCompile(`^(?:` +
+	`y|Y|yes|Yes|YES|n|N|no|No|NO|true|True|TRUE|false|False|FALSE|on|On|ON|off|Off|OFF` +
+	`|[-+]?0b[01_]+|[-+]?0[0-7_]+|[-+]?(?:0|[1-9][0-9_]*)|[-+]?0x[0-9a-fA-F_]+|[-+]?[1-9][0-9_]*(?::[0-5]?[0-9])+` +
+	`|[-+]?(?:[0-9][0-9_]*)?\.[0-9_]*(?:[eE][-+]?[0-9]+)?|[-+]?[0-9][0-9_]*(?::[0-5]?[0-9])+\.[0-9_]*` +
+	`|[-+]?\.(?:inf|Inf|INF)|\.(?:nan|NaN|NAN)` +
+	`|~|null|Null|NULL|` +
+	`|[0-9]{4}-[0-9]{1,2}-[0-9]{1,2}(?:(?:[Tt]|[ \t]+)[0-9]{1,2}:[0-9]{2}:[0-9]{2}(?:\.[0-9]*)?(?:[ \t]*(?:Z|[-+][0-9]{1,2}(?::[0-9]{2})?))?)?` +
+	`|<<|=` +
+	`)$`)
+
+// resetStyle drops the flow styles inherited from the JSON source so the document is emitted
+// in block style. Scalars are left to the encoder, which quotes strings only where needed,
+// except strings that YAML 1.1 would read as another type, which stay double-quoted.
+func resetStyle(node *yaml.Node) {
+	switch {
+	case node.Kind == yaml.ScalarNode && node.ShortTag() == "!!str" && yaml11Scalar.MatchString(node.Value):
+		node.Style = yaml.DoubleQuotedStyle
+	default:
+		node.Style = 0
+	}
+
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}